@@ -19,6 +19,7 @@ package args
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/pflag"
 	"k8s.io/gengo/args"
@@ -30,6 +31,21 @@ type CustomArgs struct {
 	// by API linter. If specified, API rule violations will be printed to report file.
 	// Otherwise default value "-" will be used which indicates stdout.
 	ReportFilename string
+
+	// RequiredByDefault sets whether struct fields without +optional or +required markers
+	// are required, pointer fields being optional unless marked +required. If unset, every
+	// field without +optional marker or omitempty json tag is required.
+	RequiredByDefault *bool
+
+	// NullablePointers marks the properties generated for pointers to primitive types
+	// (e.g. *string) as nullable, with the x-nullable extension.
+	NullablePointers bool
+
+	// DefinitionNamer maps the canonical name of a type (e.g. "example.com/widgets/v1.Widget")
+	// to the key of its definition and to the name passed to the reference callback for it
+	// (e.g. "com.example.widgets.v1.Widget"). It has no flag: generators built on this package
+	// may set it. If unset, the canonical name is used.
+	DefinitionNamer func(name string) string
 }
 
 // NewDefaults returns default arguments for the generator. Returning the arguments instead
@@ -54,9 +70,37 @@ func NewDefaults() (*args.GeneratorArgs, *CustomArgs) {
 
 // AddFlags add the generator flags to the flag set.
 func (c *CustomArgs) AddFlags(fs *pflag.FlagSet) {
+	fs.Var(&optionalBool{&c.RequiredByDefault}, "required-by-default", "Whether struct fields without +optional or +required markers are required. Pointer fields are optional unless marked +required. If not specified, every field without +optional marker or omitempty json tag is required. If specified, the fields without markers are also listed in the RequiredByDefault of their definition, for common.Config.RequiredByDefault to override the setting.")
+	fs.Lookup("required-by-default").NoOptDefVal = "true"
+	fs.BoolVar(&c.NullablePointers, "nullable-pointers", c.NullablePointers, "Mark the properties of pointer to primitive fields (e.g. *string) as nullable, with the x-nullable extension.")
 	fs.StringVarP(&c.ReportFilename, "report-filename", "r", c.ReportFilename, "Name of report file used by API linter to print API violations. Default \"-\" stands for standard output. NOTE that if valid filename other than \"-\" is specified, API linter won't return error on detected API violations. This allows further check of existing API violations without stopping the OpenAPI generation toolchain.")
 }
 
+// optionalBool is a boolean flag which distinguishes being unset from being false.
+type optionalBool struct {
+	value **bool
+}
+
+func (b *optionalBool) String() string {
+	if b.value == nil || *b.value == nil {
+		return ""
+	}
+	return strconv.FormatBool(**b.value)
+}
+
+func (b *optionalBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*b.value = &v
+	return nil
+}
+
+func (b *optionalBool) Type() string {
+	return "bool"
+}
+
 // Validate checks the given arguments.
 func Validate(genericArgs *args.GeneratorArgs) error {
 	c, ok := genericArgs.CustomArgs.(*CustomArgs)
@@ -17,6 +17,7 @@ limitations under the License.
 package aggregator
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -27,7 +28,10 @@ import (
 	"k8s.io/kube-openapi/pkg/util"
 )
 
-const gvkKey = "x-kubernetes-group-version-kind"
+const (
+	gvkKey         = "x-kubernetes-group-version-kind"
+	listMapKeysKey = "x-kubernetes-list-map-keys"
+)
 
 // usedDefinitionForSpec returns a map with all used definitions in the provided spec as keys and true as values.
 func usedDefinitionForSpec(root *spec.Swagger) map[string]bool {
@@ -138,31 +142,36 @@ func renameDefinition(s *spec.Swagger, renames map[string]string) *spec.Swagger
 // conflicts by keeping the paths of destination. It will rename definition conflicts.
 // The source is not mutated.
 func MergeSpecsIgnorePathConflict(dest, source *spec.Swagger) error {
-	return mergeSpecs(dest, source, true, true)
+	_, err := mergeSpecs(dest, source, true, true)
+	return err
 }
 
 // MergeSpecsFailOnDefinitionConflict is differ from MergeSpecs as it fails if there is
-// a definition conflict.
+// a definition conflict, i.e. definitions of the same name with different schemas. The error
+// lists all the conflicting definitions with the paths at which their schemas differ.
 // The source is not mutated.
 func MergeSpecsFailOnDefinitionConflict(dest, source *spec.Swagger) error {
-	return mergeSpecs(dest, source, false, false)
+	_, err := mergeSpecs(dest, source, false, false)
+	return err
 }
 
 // MergeSpecs copies paths and definitions from source to dest, rename definitions if needed.
 // dest will be mutated, and source will not be changed. It will fail on path conflicts.
 // The source is not mutated.
 func MergeSpecs(dest, source *spec.Swagger) error {
-	return mergeSpecs(dest, source, true, false)
+	_, err := mergeSpecs(dest, source, true, false)
+	return err
 }
 
-// mergeSpecs merges source into dest while resolving conflicts.
+// mergeSpecs merges source into dest while resolving conflicts, and returns the new names of the
+// definitions of source which were renamed.
 // The source is not mutated.
-func mergeSpecs(dest, source *spec.Swagger, renameModelConflicts, ignorePathConflicts bool) (err error) {
+func mergeSpecs(dest, source *spec.Swagger, renameModelConflicts, ignorePathConflicts bool) (renames map[string]string, err error) {
 	// Paths may be empty, due to [ACL constraints](http://goo.gl/8us55a#securityFiltering).
 	if source.Paths == nil {
 		// When a source spec does not have any path, that means none of the definitions
 		// are used thus we should not do anything
-		return nil
+		return nil, nil
 	}
 	if dest.Paths == nil {
 		dest.Paths = &spec.Paths{}
@@ -179,7 +188,7 @@ func mergeSpecs(dest, source *spec.Swagger, renameModelConflicts, ignorePathConf
 		}
 		if len(keepPaths) == 0 {
 			// There is nothing to merge. All paths are conflicting.
-			return nil
+			return nil, nil
 		}
 		if hasConflictingPath {
 			source = FilterSpecByPathsWithoutSideEffects(source, keepPaths)
@@ -191,7 +200,8 @@ func mergeSpecs(dest, source *spec.Swagger, renameModelConflicts, ignorePathConf
 	for k := range dest.Definitions {
 		usedNames[k] = true
 	}
-	renames := map[string]string{}
+	renames = map[string]string{}
+	var conflicts []string
 DEFINITIONLOOP:
 	for k, v := range source.Definitions {
 		existing, found := dest.Definitions[k]
@@ -199,9 +209,9 @@ DEFINITIONLOOP:
 			// skip for now, we copy them after the rename loop
 			continue
 		}
-
 		if !renameModelConflicts {
-			return fmt.Errorf("model name conflict in merging OpenAPI spec: %s", k)
+			conflicts = append(conflicts, definitionConflict(k, &existing, &v))
+			continue
 		}
 
 		// Reuse previously renamed model if one exists
@@ -226,6 +236,10 @@ DEFINITIONLOOP:
 		renames[k] = newName
 		usedNames[newName] = true
 	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("model name conflict in merging OpenAPI spec: %s", strings.Join(conflicts, "; "))
+	}
 	source = renameDefinition(source, renames)
 
 	// now without conflict (modulo different GVKs), copy definitions to dest
@@ -236,16 +250,23 @@ DEFINITIONLOOP:
 			}
 			dest.Definitions[k] = v
 		} else if merged, changed, err := mergedGVKs(&existing, &v); err != nil {
-			return err
+			return nil, err
 		} else if changed {
-			existing.Extensions[gvkKey] = merged
+			// replace rather than modify the extensions, which may be shared with other specs
+			extensions := make(spec.Extensions, len(existing.Extensions)+1)
+			for ek, ev := range existing.Extensions {
+				extensions[ek] = ev
+			}
+			extensions[gvkKey] = merged
+			existing.Extensions = extensions
+			dest.Definitions[k] = existing
 		}
 	}
 
 	// Check for path conflicts
 	for k, v := range source.Paths.Paths {
 		if _, found := dest.Paths.Paths[k]; found {
-			return fmt.Errorf("unable to merge: duplicated path %s", k)
+			return nil, fmt.Errorf("unable to merge: duplicated path %s", k)
 		}
 		// PathItem may be empty, due to [ACL constraints](http://goo.gl/8us55a#securityFiltering).
 		if dest.Paths.Paths == nil {
@@ -254,7 +275,7 @@ DEFINITIONLOOP:
 		dest.Paths.Paths[k] = v
 	}
 
-	return nil
+	return renames, nil
 }
 
 // deepEqualDefinitionsModuloGVKs compares s1 and s2, but ignores the x-kubernetes-group-version-kind extension.
@@ -300,6 +321,135 @@ func deepEqualDefinitionsModuloGVKs(s1, s2 *spec.Schema) bool {
 	return reflect.DeepEqual(s1, s2)
 }
 
+// definitionConflict describes the conflict between two schemas of the definition name, listing the
+// JSON paths at which they differ (x-kubernetes-group-version-kind aside), and their array properties
+// with mismatching x-kubernetes-list-map-keys.
+func definitionConflict(name string, s1, s2 *spec.Schema) string {
+	conflict := name
+	if paths := differingJSONPaths(s1, s2); len(paths) > 0 {
+		conflict = fmt.Sprintf("%s differs at %s", name, strings.Join(paths, ", "))
+	}
+	if details := listMapKeysConflicts(s1, s2); len(details) > 0 {
+		conflict = fmt.Sprintf("%s (%s)", conflict, strings.Join(details, ", "))
+	}
+	return conflict
+}
+
+// differingJSONPaths returns the sorted JSON paths at which s1 and s2 differ, ignoring their GVKs.
+func differingJSONPaths(s1, s2 *spec.Schema) []string {
+	var v1, v2 interface{}
+	if err := roundTripJSON(s1, &v1); err != nil {
+		return nil
+	}
+	if err := roundTripJSON(s2, &v2); err != nil {
+		return nil
+	}
+	for _, v := range []interface{}{v1, v2} {
+		if m, ok := v.(map[string]interface{}); ok {
+			delete(m, gvkKey)
+		}
+	}
+	var paths []string
+	diffJSONPaths("", v1, v2, &paths)
+	sort.Strings(paths)
+	return paths
+}
+
+func roundTripJSON(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// diffJSONPaths appends to paths the dotted paths at which the JSON values v1 and v2 differ.
+// Objects are compared key by key, other values as a whole.
+func diffJSONPaths(path string, v1, v2 interface{}, paths *[]string) {
+	m1, ok1 := v1.(map[string]interface{})
+	m2, ok2 := v2.(map[string]interface{})
+	if ok1 && ok2 {
+		for k, e1 := range m1 {
+			diffJSONPaths(joinJSONPath(path, k), e1, m2[k], paths)
+		}
+		for k := range m2 {
+			if _, found := m1[k]; !found {
+				*paths = append(*paths, joinJSONPath(path, k))
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(v1, v2) {
+		if path == "" {
+			path = "."
+		}
+		*paths = append(*paths, path)
+	}
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// listMapKeysConflicts describes, in the order of their names, the array properties of both s1 and s2
+// which do not have the same x-kubernetes-list-map-keys in both schemas. Clients key the items of such
+// lists by these keys, which makes it unsafe to serve both definitions under the same name.
+func listMapKeysConflicts(s1, s2 *spec.Schema) []string {
+	names := make([]string, 0, len(s1.Properties))
+	for name := range s1.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var conflicts []string
+	for _, name := range names {
+		p1 := s1.Properties[name]
+		p2, ok := s2.Properties[name]
+		if !ok || !p1.Type.Contains("array") || !p2.Type.Contains("array") {
+			continue
+		}
+		keys1, err := listMapKeys(&p1)
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("property %s: %v", name, err))
+			continue
+		}
+		keys2, err := listMapKeys(&p2)
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("property %s: %v", name, err))
+			continue
+		}
+		if !reflect.DeepEqual(keys1, keys2) {
+			conflicts = append(conflicts, fmt.Sprintf("property %s has mismatching %s: %v and %v", name, listMapKeysKey, keys1, keys2))
+		}
+	}
+	return conflicts
+}
+
+// listMapKeys returns the x-kubernetes-list-map-keys of the schema, or nil.
+func listMapKeys(s *spec.Schema) ([]string, error) {
+	v, found := s.Extensions[listMapKeysKey]
+	if !found {
+		return nil, nil
+	}
+	switch keys := v.(type) {
+	case []string:
+		return keys, nil
+	case []interface{}:
+		ret := make([]string, 0, len(keys))
+		for _, k := range keys {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string in %s, got: %#v", listMapKeysKey, k)
+			}
+			ret = append(ret, key)
+		}
+		return ret, nil
+	}
+	return nil, fmt.Errorf("expected slice of strings in %s, got: %#v", listMapKeysKey, v)
+}
+
 // mergedGVKs merges the x-kubernetes-group-version-kind slices and returns the result, and whether
 // s1's x-kubernetes-group-version-kind slice was changed at all.
 func mergedGVKs(s1, s2 *spec.Schema) (interface{}, bool, error) {
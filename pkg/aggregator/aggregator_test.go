@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	jsoniter "github.com/json-iterator/go"
@@ -1556,6 +1557,69 @@ definitions:
 	ast.Error(MergeSpecsFailOnDefinitionConflict(fooSpec, barSpec))
 }
 
+func TestMergeSpecsFailOnDefinitionConflictListsDifferences(t *testing.T) {
+	var fooSpec, barSpec *spec.Swagger
+	yaml.Unmarshal([]byte(`
+swagger: "2.0"
+paths:
+  /foo:
+    get:
+      operationId: "fooTest"
+      responses:
+        200:
+          description: "OK"
+definitions:
+  Foo:
+    type: "object"
+    x-kubernetes-group-version-kind:
+    - group: "foo"
+      version: "v1"
+      kind: "Foo"
+    properties:
+      id:
+        type: "integer"
+        format: "int64"
+  Bar:
+    type: "object"
+    description: "Bar"
+  Same:
+    type: "string"
+`), &fooSpec)
+
+	yaml.Unmarshal([]byte(`
+swagger: "2.0"
+paths:
+  /bar:
+    get:
+      operationId: "barTest"
+      responses:
+        200:
+          description: "OK"
+definitions:
+  Foo:
+    type: "object"
+    x-kubernetes-group-version-kind:
+    - group: "bar"
+      version: "v1"
+      kind: "Foo"
+    properties:
+      id:
+        type: "string"
+      name:
+        type: "string"
+  Bar:
+    type: "object"
+    description: "Other Bar"
+  Same:
+    type: "string"
+`), &barSpec)
+
+	err := MergeSpecsFailOnDefinitionConflict(fooSpec, barSpec)
+	if assert.Error(t, err) {
+		assert.Equal(t, "model name conflict in merging OpenAPI spec: Bar differs at description; Foo differs at properties.id.format, properties.id.type, properties.name", err.Error())
+	}
+}
+
 func TestMergeSpecsIgnorePathConflicts(t *testing.T) {
 	var fooSpec, barSpec, expected *spec.Swagger
 	yaml.Unmarshal([]byte(`
@@ -2154,3 +2218,91 @@ func TestDeepEqualDefinitionsModuloGVKs(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeSpecsListMapKeysConflicts(t *testing.T) {
+	newSpec := func(keys ...string) *spec.Swagger {
+		definition := func() spec.Schema {
+			schema := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: map[string]spec.Schema{}}}
+			for _, property := range []string{"b", "a"} {
+				items := spec.ArrayProperty(spec.RefSchema("#/definitions/Item"))
+				items.Extensions = spec.Extensions{"x-kubernetes-list-type": "map", listMapKeysKey: keys}
+				schema.Properties[property] = *items
+			}
+			return schema
+		}
+		return &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Paths:       &spec.Paths{Paths: map[string]spec.PathItem{}},
+			Definitions: spec.Definitions{"Foo": definition(), "Bar": definition()},
+		}}
+	}
+
+	want := "model name conflict in merging OpenAPI spec: " +
+		"Bar differs at properties.a.x-kubernetes-list-map-keys, properties.b.x-kubernetes-list-map-keys " +
+		"(property a has mismatching x-kubernetes-list-map-keys: [name] and [name namespace], " +
+		"property b has mismatching x-kubernetes-list-map-keys: [name] and [name namespace]); " +
+		"Foo differs at properties.a.x-kubernetes-list-map-keys, properties.b.x-kubernetes-list-map-keys " +
+		"(property a has mismatching x-kubernetes-list-map-keys: [name] and [name namespace], " +
+		"property b has mismatching x-kubernetes-list-map-keys: [name] and [name namespace])"
+	// the error does not depend on the order of iteration of maps
+	for i := 0; i < 10; i++ {
+		err := MergeSpecsFailOnDefinitionConflict(newSpec("name"), newSpec("name", "namespace"))
+		if assert.Error(t, err) {
+			assert.Equal(t, want, err.Error())
+		}
+	}
+}
+
+func TestMergeSpecsListMapKeys(t *testing.T) {
+	newSpec := func(keys interface{}, description string) *spec.Swagger {
+		items := spec.ArrayProperty(spec.RefSchema("#/definitions/Item"))
+		items.Extensions = spec.Extensions{"x-kubernetes-list-type": "map"}
+		if keys != nil {
+			items.Extensions[listMapKeysKey] = keys
+		}
+		return &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{}},
+			Definitions: spec.Definitions{
+				"Foo": {SchemaProps: spec.SchemaProps{
+					Description: description,
+					Type:        []string{"object"},
+					Properties:  map[string]spec.Schema{"items": *items},
+				}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		destKeys    interface{}
+		sourceKeys  interface{}
+		sourceDesc  string
+		wantRename  bool
+		wantFailErr string
+	}{
+		{"identical", []interface{}{"name"}, []interface{}{"name"}, "foo", false, ""},
+		{"identical keys, other differences", []interface{}{"name"}, []string{"name"}, "other foo", true, "model name conflict"},
+		{"mismatching keys", []interface{}{"name"}, []interface{}{"name", "namespace"}, "foo", true, "mismatching x-kubernetes-list-map-keys"},
+		{"missing keys", []interface{}{"name"}, nil, "foo", true, "mismatching x-kubernetes-list-map-keys"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// renaming the conflicting definition is always safe
+			dest := newSpec(tt.destKeys, "foo")
+			if err := MergeSpecs(dest, newSpec(tt.sourceKeys, tt.sourceDesc)); err != nil {
+				t.Fatalf("MergeSpecs() unexpected error: %v", err)
+			}
+			if _, renamed := dest.Definitions["Foo_v2"]; renamed != tt.wantRename {
+				t.Errorf("MergeSpecs() renamed = %v, want %v", renamed, tt.wantRename)
+			}
+
+			err := MergeSpecsFailOnDefinitionConflict(newSpec(tt.destKeys, "foo"), newSpec(tt.sourceKeys, tt.sourceDesc))
+			if tt.wantFailErr == "" {
+				if err != nil {
+					t.Errorf("MergeSpecsFailOnDefinitionConflict() unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantFailErr) {
+				t.Errorf("MergeSpecsFailOnDefinitionConflict() error = %v, want it to contain %q", err, tt.wantFailErr)
+			}
+		})
+	}
+}
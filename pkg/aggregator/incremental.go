@@ -0,0 +1,258 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// Aggregator maintains the merge of the specs of several services, which are added, updated and
+// removed one at a time, without merging the other specs again. Specs are merged as by MergeSpecs:
+// conflicting definitions are renamed, and duplicated paths fail. Removing a service withdraws its
+// paths, and its definitions unless other services provide them too. It is safe for concurrent use.
+type Aggregator struct {
+	lock   sync.Mutex
+	merged *spec.Swagger
+	// services holds the services merged, by name
+	services map[string]*aggregatedService
+	// pathOwners maps the paths of the merged spec to the name of the service providing them
+	pathOwners map[string]string
+	// definitionOwners maps the definitions of the merged spec to the names of the services providing
+	// them, in the order they were added
+	definitionOwners map[string][]string
+}
+
+// aggregatedService is a service merged by an Aggregator.
+type aggregatedService struct {
+	spec  *spec.Swagger
+	paths []string
+	// definitions maps the names of the definitions of the service in the merged spec to their names in
+	// the spec of the service, which differ if they were renamed to avoid a conflict
+	definitions map[string]string
+}
+
+// NewAggregator returns an Aggregator without any service. The merged spec gets its fields other than
+// the paths and the definitions, e.g. its info, from base; the paths and definitions of base are ignored.
+func NewAggregator(base *spec.Swagger) *Aggregator {
+	merged := *base
+	merged.Paths = &spec.Paths{Paths: map[string]spec.PathItem{}}
+	merged.Definitions = spec.Definitions{}
+	return &Aggregator{
+		merged:           &merged,
+		services:         map[string]*aggregatedService{},
+		pathOwners:       map[string]string{},
+		definitionOwners: map[string][]string{},
+	}
+}
+
+// AddOrUpdate merges the spec of the service name, replacing its previous spec if any. The spec must
+// not be modified afterwards. On error, the merged spec is left unchanged.
+func (a *Aggregator) AddOrUpdate(name string, s *spec.Swagger) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if s.Paths != nil {
+		for path := range s.Paths.Paths {
+			if owner, found := a.pathOwners[path]; found && owner != name {
+				return fmt.Errorf("unable to merge service %s: duplicated path %s, provided by service %s", name, path, owner)
+			}
+		}
+	}
+	snapshot := a.snapshot()
+	if _, updating := a.services[name]; updating {
+		a.remove(name)
+	}
+	if err := a.add(name, s); err != nil {
+		a.restore(snapshot)
+		return fmt.Errorf("unable to merge service %s: %v", name, err)
+	}
+	return nil
+}
+
+// Remove withdraws the paths and definitions of the service name from the merged spec. Definitions
+// also provided by other services are kept. Removing an unknown service does nothing.
+func (a *Aggregator) Remove(name string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, found := a.services[name]; found {
+		a.remove(name)
+	}
+}
+
+// Spec returns the merged spec. Its paths and definitions maps are copies, while their values are
+// shared with the Aggregator and with the specs of the services, and must not be modified.
+func (a *Aggregator) Spec() *spec.Swagger {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return copyPathsAndDefinitions(a.merged)
+}
+
+// copyPathsAndDefinitions returns a copy of s with copies of its paths and definitions maps.
+func copyPathsAndDefinitions(s *spec.Swagger) *spec.Swagger {
+	ret := *s
+	ret.Paths = &spec.Paths{
+		VendorExtensible: s.Paths.VendorExtensible,
+		Paths:            make(map[string]spec.PathItem, len(s.Paths.Paths)),
+	}
+	for k, v := range s.Paths.Paths {
+		ret.Paths.Paths[k] = v
+	}
+	ret.Definitions = make(spec.Definitions, len(s.Definitions))
+	for k, v := range s.Definitions {
+		ret.Definitions[k] = v
+	}
+	return &ret
+}
+
+// add merges the spec of the service name, which must not be merged already. On error, the state of
+// the Aggregator is undefined and must be restored from a snapshot.
+func (a *Aggregator) add(name string, s *spec.Swagger) error {
+	renames, err := mergeSpecs(a.merged, s, true, false)
+	if err != nil {
+		return err
+	}
+	mergedName := func(k string) string {
+		if renamed, ok := renames[k]; ok {
+			return renamed
+		}
+		return k
+	}
+
+	svc := &aggregatedService{spec: s, definitions: map[string]string{}}
+	a.services[name] = svc
+	if s.Paths == nil {
+		// nothing was merged, see mergeSpecs
+		return nil
+	}
+	for path := range s.Paths.Paths {
+		svc.paths = append(svc.paths, path)
+		a.pathOwners[path] = name
+	}
+	sort.Strings(svc.paths)
+	for k := range s.Definitions {
+		n := mergedName(k)
+		svc.definitions[n] = k
+		a.definitionOwners[n] = append(a.definitionOwners[n], name)
+	}
+	return nil
+}
+
+// aggregatorState is a snapshot of the state of an Aggregator.
+type aggregatorState struct {
+	merged           *spec.Swagger
+	services         map[string]*aggregatedService
+	pathOwners       map[string]string
+	definitionOwners map[string][]string
+}
+
+// snapshot returns a copy of the state of the Aggregator, which is left unchanged by later calls to add
+// and remove. The values of the maps are shared: add and remove, as well as mergeSpecs, replace them
+// rather than modifying them.
+func (a *Aggregator) snapshot() aggregatorState {
+	state := aggregatorState{
+		merged:           copyPathsAndDefinitions(a.merged),
+		services:         make(map[string]*aggregatedService, len(a.services)),
+		pathOwners:       make(map[string]string, len(a.pathOwners)),
+		definitionOwners: make(map[string][]string, len(a.definitionOwners)),
+	}
+	for k, v := range a.services {
+		state.services[k] = v
+	}
+	for k, v := range a.pathOwners {
+		state.pathOwners[k] = v
+	}
+	for k, v := range a.definitionOwners {
+		state.definitionOwners[k] = v
+	}
+	return state
+}
+
+// restore resets the state of the Aggregator to the snapshot.
+func (a *Aggregator) restore(state aggregatorState) {
+	a.merged = state.merged
+	a.services = state.services
+	a.pathOwners = state.pathOwners
+	a.definitionOwners = state.definitionOwners
+}
+
+// remove withdraws the service name, which must be merged.
+func (a *Aggregator) remove(name string) {
+	svc := a.services[name]
+	delete(a.services, name)
+	for _, path := range svc.paths {
+		delete(a.merged.Paths.Paths, path)
+		delete(a.pathOwners, path)
+	}
+	for n := range svc.definitions {
+		var owners []string
+		for _, owner := range a.definitionOwners[n] {
+			if owner != name {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) == 0 {
+			delete(a.definitionOwners, n)
+			delete(a.merged.Definitions, n)
+			continue
+		}
+		a.definitionOwners[n] = owners
+		a.resetGVKs(n)
+	}
+}
+
+// resetGVKs sets the GVK extension of the definition n of the merged spec to the merge of the GVKs
+// of the definitions of its owners.
+func (a *Aggregator) resetGVKs(n string) {
+	var gvks spec.Schema
+	for i, owner := range a.definitionOwners[n] {
+		svc := a.services[owner]
+		def := svc.spec.Definitions[svc.definitions[n]]
+		if i == 0 {
+			gvks.Extensions = spec.Extensions{}
+			if v, found := def.Extensions[gvkKey]; found {
+				gvks.Extensions[gvkKey] = v
+			}
+			continue
+		}
+		// the GVKs merged successfully when the owners were added
+		if merged, changed, err := mergedGVKs(&gvks, &def); err == nil && changed {
+			gvks.Extensions[gvkKey] = merged
+		}
+	}
+
+	def := a.merged.Definitions[n]
+	extensions := make(spec.Extensions, len(def.Extensions))
+	for k, v := range def.Extensions {
+		if k != gvkKey {
+			extensions[k] = v
+		}
+	}
+	if v, found := gvks.Extensions[gvkKey]; found {
+		extensions[gvkKey] = v
+	}
+	if len(extensions) == 0 {
+		extensions = nil
+	}
+	def.Extensions = extensions
+	a.merged.Definitions[n] = def
+}
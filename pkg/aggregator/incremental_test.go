@@ -0,0 +1,208 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregator
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/yaml"
+)
+
+func mustParseSpec(t *testing.T, s string) *spec.Swagger {
+	var ret *spec.Swagger
+	require.NoError(t, yaml.Unmarshal([]byte(s), &ret))
+	return ret
+}
+
+func specPathsAndDefinitions(s *spec.Swagger) (paths []string, definitions []string) {
+	for k := range s.Paths.Paths {
+		paths = append(paths, k)
+	}
+	for k := range s.Definitions {
+		definitions = append(definitions, k)
+	}
+	sort.Strings(paths)
+	sort.Strings(definitions)
+	return paths, definitions
+}
+
+func TestAggregator(t *testing.T) {
+	specA := mustParseSpec(t, `
+swagger: "2.0"
+paths:
+  /apis/a:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Foo"
+definitions:
+  Foo:
+    type: object
+    properties:
+      status:
+        $ref: "#/definitions/Status"
+  Status:
+    type: object
+    x-kubernetes-group-version-kind:
+    - group: a
+      version: v1
+      kind: Status
+`)
+	specB := mustParseSpec(t, `
+swagger: "2.0"
+paths:
+  /apis/b:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Foo"
+  /apis/b/bar:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Bar"
+definitions:
+  Foo:
+    type: string
+  Bar:
+    type: object
+    properties:
+      status:
+        $ref: "#/definitions/Status"
+  Status:
+    type: object
+    x-kubernetes-group-version-kind:
+    - group: b
+      version: v1
+      kind: Status
+`)
+	specB2 := mustParseSpec(t, `
+swagger: "2.0"
+paths:
+  /apis/b/v2:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Status"
+definitions:
+  Status:
+    type: object
+    x-kubernetes-group-version-kind:
+    - group: b
+      version: v2
+      kind: Status
+`)
+	gvks := func(groupVersions ...string) interface{} {
+		var ret []interface{}
+		for i := 0; i < len(groupVersions); i += 2 {
+			ret = append(ret, map[string]interface{}{"group": groupVersions[i], "version": groupVersions[i+1], "kind": "Status"})
+		}
+		return ret
+	}
+
+	a := NewAggregator(&spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0", Info: &spec.Info{InfoProps: spec.InfoProps{Title: "merged"}}}})
+
+	// add
+	require.NoError(t, a.AddOrUpdate("a", specA))
+	require.NoError(t, a.AddOrUpdate("b", specB))
+	merged := a.Spec()
+	assert.Equal(t, "merged", merged.Info.Title)
+	paths, definitions := specPathsAndDefinitions(merged)
+	assert.Equal(t, []string{"/apis/a", "/apis/b", "/apis/b/bar"}, paths)
+	assert.Equal(t, []string{"Bar", "Foo", "Foo_v2", "Status"}, definitions)
+	assert.Equal(t, "#/definitions/Foo_v2", merged.Paths.Paths["/apis/b"].Get.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	assert.Equal(t, gvks("a", "v1", "b", "v1"), merged.Definitions["Status"].Extensions[gvkKey])
+	// the specs of the services are not modified
+	assert.Equal(t, gvks("a", "v1"), specA.Definitions["Status"].Extensions[gvkKey])
+
+	// a failing merge leaves the merged spec unchanged, including renamed definitions and merged GVKs
+	specC := mustParseSpec(t, `
+swagger: "2.0"
+paths:
+  /apis/c:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Foo"
+definitions:
+  Foo:
+    type: integer
+  Bar:
+    type: object
+    properties:
+      status:
+        $ref: "#/definitions/Status"
+    x-kubernetes-group-version-kind:
+    - group: c
+      version: v1
+      kind: Bar
+  Status:
+    type: object
+    x-kubernetes-group-version-kind:
+    - invalid
+`)
+	before, err := json.Marshal(a.Spec())
+	require.NoError(t, err)
+	err = a.AddOrUpdate("c", specC)
+	assert.Error(t, err)
+	after, err := json.Marshal(a.Spec())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+
+	// update
+	require.NoError(t, a.AddOrUpdate("b", specB2))
+	merged = a.Spec()
+	paths, definitions = specPathsAndDefinitions(merged)
+	assert.Equal(t, []string{"/apis/a", "/apis/b/v2"}, paths)
+	assert.Equal(t, []string{"Foo", "Status"}, definitions)
+	assert.Equal(t, gvks("a", "v1", "b", "v2"), merged.Definitions["Status"].Extensions[gvkKey])
+
+	// a duplicated path fails and leaves the merged spec unchanged
+	err = a.AddOrUpdate("c", specA)
+	assert.EqualError(t, err, "unable to merge service c: duplicated path /apis/a, provided by service a")
+	assert.Equal(t, merged, a.Spec())
+
+	// remove
+	a.Remove("a")
+	merged = a.Spec()
+	paths, definitions = specPathsAndDefinitions(merged)
+	assert.Equal(t, []string{"/apis/b/v2"}, paths)
+	assert.Equal(t, []string{"Status"}, definitions)
+	assert.Equal(t, gvks("b", "v2"), merged.Definitions["Status"].Extensions[gvkKey])
+
+	a.Remove("b")
+	a.Remove("unknown")
+	merged = a.Spec()
+	assert.Empty(t, merged.Paths.Paths)
+	assert.Empty(t, merged.Definitions)
+
+	// the removed services can be added again
+	require.NoError(t, a.AddOrUpdate("b", specB))
+	paths, definitions = specPathsAndDefinitions(a.Spec())
+	assert.Equal(t, []string{"/apis/b", "/apis/b/bar"}, paths)
+	assert.Equal(t, []string{"Bar", "Foo", "Status"}, definitions)
+}
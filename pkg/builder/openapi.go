@@ -20,7 +20,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	restful "github.com/emicklei/go-restful"
 
@@ -31,6 +35,9 @@ import (
 
 const (
 	OpenAPIVersion = "2.0"
+
+	// maxSummaryLength is the length above which summaries derived from descriptions are truncated.
+	maxSummaryLength = 120
 )
 
 type openAPI struct {
@@ -38,6 +45,22 @@ type openAPI struct {
 	swagger      *spec.Swagger
 	protocolList []string
 	definitions  map[string]common.OpenAPIDefinition
+	// excluded holds the names of the definitions left out by config.IncludeDefinition
+	excluded map[string]bool
+	// pending holds the definitions referenced by the spec whose schema is yet to be built if
+	// config.BuildDefinitionsConcurrently is set, in the order they were referenced
+	pending []pendingDefinition
+	// pendingNames holds the names in the spec of the pending definitions
+	pendingNames map[string]bool
+	// definitionNames maps the names of the definitions in the spec to the names of their types
+	definitionNames map[string]string
+}
+
+// pendingDefinition is a definition whose schema is built by buildPendingDefinitions.
+type pendingDefinition struct {
+	uniqueName string
+	extensions spec.Extensions
+	item       common.OpenAPIDefinition
 }
 
 // BuildOpenAPISpec builds OpenAPI spec given a list of webservices (containing routes) and common.Config to customize it.
@@ -81,10 +104,50 @@ func BuildOpenAPIDefinitionsForResources(config *common.Config, names ...string)
 	return o.finalizeSwagger()
 }
 
+// BuildDefinitionsByPackage builds the definitions of all the types provided by config.GetDefinitions,
+// grouped by the package of their definition names, i.e. the prefix of the names returned by
+// config.GetDefinitionName up to the last dot. Each group is self-contained: the definitions of the other
+// packages which its definitions depend on are duplicated into it.
+func BuildDefinitionsByPackage(config *common.Config) (map[string]spec.Definitions, error) {
+	o := newOpenAPI(config)
+	packages := map[string][]string{}
+	for name := range o.definitions {
+		defName, _ := o.config.GetDefinitionName(name)
+		if !o.includeDefinition(defName) {
+			continue
+		}
+		pkg := ""
+		if i := strings.LastIndex(defName, "."); i >= 0 {
+			pkg = defName[:i]
+		}
+		packages[pkg] = append(packages[pkg], name)
+	}
+
+	ret := make(map[string]spec.Definitions, len(packages))
+	for pkg, names := range packages {
+		sort.Strings(names)
+		g := o.withEmptySpec()
+		for _, name := range names {
+			if _, err := g.toSchema(name); err != nil {
+				return nil, err
+			}
+		}
+		swagger, err := g.finalizeSwagger()
+		if err != nil {
+			return nil, err
+		}
+		ret[pkg] = swagger.Definitions
+	}
+	return ret, nil
+}
+
 // newOpenAPI sets up the openAPI object so we can build the spec.
 func newOpenAPI(config *common.Config) openAPI {
 	o := openAPI{
-		config: config,
+		config:          config,
+		excluded:        map[string]bool{},
+		pendingNames:    map[string]bool{},
+		definitionNames: map[string]string{},
 		swagger: &spec.Swagger{
 			SwaggerProps: spec.SwaggerProps{
 				Swagger:     OpenAPIVersion,
@@ -95,6 +158,14 @@ func newOpenAPI(config *common.Config) openAPI {
 			},
 		},
 	}
+	if o.config.InfoVersionFunc != nil {
+		info := spec.Info{}
+		if o.config.Info != nil {
+			info = *o.config.Info
+		}
+		info.Version = o.config.InfoVersionFunc()
+		o.swagger.Info = &info
+	}
 	if o.config.GetOperationIDAndTags == nil {
 		o.config.GetOperationIDAndTags = func(r *restful.Route) (string, []string, error) {
 			return r.Operation, nil, nil
@@ -115,13 +186,51 @@ func newOpenAPI(config *common.Config) openAPI {
 	return o
 }
 
+// withEmptySpec returns a copy of o building a new spec, from the same definitions and config.
+func (o openAPI) withEmptySpec() openAPI {
+	swagger := *o.swagger
+	swagger.Definitions = spec.Definitions{}
+	swagger.Paths = &spec.Paths{Paths: map[string]spec.PathItem{}}
+	o.swagger = &swagger
+	o.excluded = map[string]bool{}
+	o.pending = nil
+	o.pendingNames = map[string]bool{}
+	o.definitionNames = map[string]string{}
+	return o
+}
+
 // finalizeSwagger is called after the spec is built and returns the final spec.
 // NOTE: finalizeSwagger also make changes to the final spec, as specified in the config.
 func (o *openAPI) finalizeSwagger() (*spec.Swagger, error) {
+	if err := o.buildPendingDefinitions(); err != nil {
+		return nil, err
+	}
+	if o.config.BasePath != "" && !strings.HasPrefix(o.config.BasePath, "/") {
+		return nil, fmt.Errorf("invalid base path %q: must start with \"/\"", o.config.BasePath)
+	}
+	o.swagger.Host = o.config.Host
+	o.swagger.BasePath = o.config.BasePath
 	if o.config.SecurityDefinitions != nil {
 		o.swagger.SecurityDefinitions = *o.config.SecurityDefinitions
 		o.swagger.Security = o.config.DefaultSecurity
 	}
+	// the definitions built concurrently are already pruned and checked by buildPendingDefinitions
+	if len(o.excluded) > 0 && !o.config.BuildDefinitionsConcurrently {
+		for name, schema := range o.swagger.Definitions {
+			o.swagger.Definitions[name], _ = pruneExcludedRefs(schema, o.excluded)
+		}
+	}
+	if !o.config.BuildDefinitionsConcurrently {
+		if err := checkListTypes(o.swagger.Definitions, o.config.ExpectedListTypes); err != nil {
+			return nil, err
+		}
+	}
+	if o.config.HoistInlineSchemasMinSize > 0 {
+		o.swagger.Definitions = hoistInlineSchemas(o.swagger.Definitions, o.config.HoistInlineSchemasMinSize)
+	}
+	if o.config.InlineArrayItemRefs {
+		o.swagger.Definitions = inlineArrayItemRefs(o.swagger.Definitions)
+	}
 	if o.config.PostProcessSpec != nil {
 		var err error
 		o.swagger, err = o.config.PostProcessSpec(o.swagger)
@@ -135,30 +244,32 @@ func (o *openAPI) finalizeSwagger() (*spec.Swagger, error) {
 
 func (o *openAPI) buildDefinitionRecursively(name string) error {
 	uniqueName, extensions := o.config.GetDefinitionName(name)
-	if _, ok := o.swagger.Definitions[uniqueName]; ok {
+	if typeName, ok := o.definitionNames[uniqueName]; ok && typeName != name {
+		return fmt.Errorf("definition name %s is used by both %s and %s, GetDefinitionName must return unique names", uniqueName, typeName, name)
+	}
+	if _, ok := o.swagger.Definitions[uniqueName]; ok || o.pendingNames[uniqueName] {
 		return nil
 	}
+	o.definitionNames[uniqueName] = name
+	if !o.includeDefinition(uniqueName) {
+		return fmt.Errorf("definition %s is excluded by IncludeDefinition but is referenced by a route or requested explicitly", uniqueName)
+	}
 	if item, ok := o.definitions[name]; ok {
-		schema := spec.Schema{
-			VendorExtensible:   item.Schema.VendorExtensible,
-			SchemaProps:        item.Schema.SchemaProps,
-			SwaggerSchemaProps: item.Schema.SwaggerSchemaProps,
-		}
-		if extensions != nil {
-			if schema.Extensions == nil {
-				schema.Extensions = spec.Extensions{}
-			}
-			for k, v := range extensions {
-				schema.Extensions[k] = v
-			}
-		}
-		if v, ok := item.Schema.Extensions[common.ExtensionV2Schema]; ok {
-			if v2Schema, isOpenAPISchema := v.(spec.Schema); isOpenAPISchema {
-				schema = v2Schema
+		if o.config.BuildDefinitionsConcurrently {
+			o.pending = append(o.pending, pendingDefinition{uniqueName: uniqueName, extensions: extensions, item: item})
+			o.pendingNames[uniqueName] = true
+		} else {
+			schema, err := o.buildDefinitionSchema(uniqueName, extensions, item)
+			if err != nil {
+				return err
 			}
+			o.swagger.Definitions[uniqueName] = schema
 		}
-		o.swagger.Definitions[uniqueName] = schema
 		for _, v := range item.Dependencies {
+			if depName, _ := o.config.GetDefinitionName(v); !o.includeDefinition(depName) {
+				o.excluded[depName] = true
+				continue
+			}
 			if err := o.buildDefinitionRecursively(v); err != nil {
 				return err
 			}
@@ -169,6 +280,209 @@ func (o *openAPI) buildDefinitionRecursively(name string) error {
 	return nil
 }
 
+// buildDefinitionSchema returns the schema of the definition named uniqueName in the spec. It only
+// depends on its arguments and on the config, so that definitions can be built concurrently.
+func (o *openAPI) buildDefinitionSchema(uniqueName string, extensions spec.Extensions, item common.OpenAPIDefinition) (spec.Schema, error) {
+	schema := spec.Schema{
+		VendorExtensible:   item.Schema.VendorExtensible,
+		SchemaProps:        item.Schema.SchemaProps,
+		SwaggerSchemaProps: item.Schema.SwaggerSchemaProps,
+	}
+	if extensions != nil {
+		if schema.Extensions == nil {
+			schema.Extensions = spec.Extensions{}
+		}
+		for k, v := range extensions {
+			schema.Extensions[k] = v
+		}
+	}
+	if v, ok := item.Schema.Extensions[common.ExtensionV2Schema]; ok {
+		if v2Schema, isOpenAPISchema := v.(spec.Schema); isOpenAPISchema {
+			schema = v2Schema
+		}
+	}
+	o.applyRequiredByDefault(&schema, item.RequiredByDefault)
+	o.applyDefaultPropertyDescriptions(&schema)
+	o.applySelectableFields(uniqueName, &schema)
+	o.applyUnions(uniqueName, &schema)
+	if err := o.postProcessSchema(uniqueName, &schema); err != nil {
+		return spec.Schema{}, err
+	}
+	sortCompositions(&schema)
+	return schema, nil
+}
+
+// buildPendingDefinitions builds the schemas of the pending definitions on config.DefinitionsConcurrency
+// goroutines and adds them to the spec. If several fail, the error of the first one referenced is returned.
+// Since all the definitions of the spec are known by then, the goroutines also prune the references to
+// excluded definitions from the schemas and check their list types, see config.ExpectedListTypes.
+func (o *openAPI) buildPendingDefinitions() error {
+	if len(o.pending) == 0 {
+		return nil
+	}
+	workers := o.config.DefinitionsConcurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(o.pending) {
+		workers = len(o.pending)
+	}
+
+	schemas := make([]spec.Schema, len(o.pending))
+	errs := make([]error, len(o.pending))
+	mismatches := make([][]string, len(o.pending))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				def := o.pending[i]
+				schemas[i], errs[i] = o.buildDefinitionSchema(def.uniqueName, def.extensions, def.item)
+				if errs[i] != nil {
+					continue
+				}
+				if len(o.excluded) > 0 {
+					schemas[i], _ = pruneExcludedRefs(schemas[i], o.excluded)
+				}
+				mismatches[i] = listTypeMismatches(def.uniqueName, schemas[i], o.config.ExpectedListTypes)
+			}
+		}()
+	}
+	for i := range o.pending {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, def := range o.pending {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		o.swagger.Definitions[def.uniqueName] = schemas[i]
+	}
+	// report the list type mismatches in the order of the names of the definitions, like checkListTypes
+	order := make([]int, len(o.pending))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return o.pending[order[a]].uniqueName < o.pending[order[b]].uniqueName })
+	var allMismatches []string
+	for _, i := range order {
+		allMismatches = append(allMismatches, mismatches[i]...)
+	}
+	o.pending = nil
+	o.pendingNames = map[string]bool{}
+	return listTypesError(allMismatches)
+}
+
+// includeDefinition returns whether the definition name is part of the spec, see config.IncludeDefinition.
+func (o *openAPI) includeDefinition(name string) bool {
+	return o.config.IncludeDefinition == nil || o.config.IncludeDefinition(name)
+}
+
+// postProcessSchema runs config.PostProcessSchema on the schema of the definition name, if set.
+func (o *openAPI) postProcessSchema(name string, schema *spec.Schema) error {
+	if o.config.PostProcessSchema == nil {
+		return nil
+	}
+	extensions := make(spec.Extensions, len(schema.Extensions))
+	for k, v := range schema.Extensions {
+		extensions[k] = v
+	}
+	schema.Extensions = extensions
+	if err := o.config.PostProcessSchema(name, schema); err != nil {
+		return fmt.Errorf("failed to post-process definition %s: %v", name, err)
+	}
+	return nil
+}
+
+// applySelectableFields adds the ExtensionSelectableFields extension to the schema of the definition
+// name if config.GetSelectableFields returns fields for it.
+func (o *openAPI) applySelectableFields(name string, schema *spec.Schema) {
+	if o.config.GetSelectableFields == nil {
+		return
+	}
+	if fields := o.config.GetSelectableFields(name); fields != nil {
+		setDefinitionExtension(schema, common.ExtensionSelectableFields, fields)
+	}
+}
+
+// applyUnions adds the ExtensionUnions extension to the schema of the definition name if
+// config.GetUnions returns unions for it.
+func (o *openAPI) applyUnions(name string, schema *spec.Schema) {
+	if o.config.GetUnions == nil {
+		return
+	}
+	if unions := o.config.GetUnions(name); unions != nil {
+		setDefinitionExtension(schema, common.ExtensionUnions, unions)
+	}
+}
+
+// setDefinitionExtension sets an extension of the schema of a definition. The extensions map is copied
+// before being modified because it is shared with the definitions returned by config.GetDefinitions.
+func setDefinitionExtension(schema *spec.Schema, key string, value interface{}) {
+	extensions := make(spec.Extensions, len(schema.Extensions)+1)
+	for k, v := range schema.Extensions {
+		extensions[k] = v
+	}
+	extensions[key] = value
+	schema.Extensions = extensions
+}
+
+// applyRequiredByDefault lists the given properties as required in the schema if config.RequiredByDefault
+// is set, and as optional otherwise, see common.OpenAPIDefinition.RequiredByDefault. The required list
+// is copied before being modified because it is shared with the definitions returned by
+// config.GetDefinitions.
+func (o *openAPI) applyRequiredByDefault(schema *spec.Schema, requiredByDefault []string) {
+	if len(requiredByDefault) == 0 {
+		return
+	}
+	byDefault := make(map[string]bool, len(requiredByDefault))
+	for _, name := range requiredByDefault {
+		byDefault[name] = true
+	}
+	required := make([]string, 0, len(schema.Required)+len(requiredByDefault))
+	listed := map[string]bool{}
+	for _, name := range schema.Required {
+		if !byDefault[name] || o.config.RequiredByDefault {
+			required = append(required, name)
+			listed[name] = true
+		}
+	}
+	if o.config.RequiredByDefault {
+		for _, name := range requiredByDefault {
+			if !listed[name] {
+				required = append(required, name)
+			}
+		}
+	}
+	if len(required) == 0 {
+		required = nil
+	}
+	schema.Required = required
+}
+
+// applyDefaultPropertyDescriptions fills empty property descriptions of the given schema using
+// config.DefaultPropertyDescriptions. The properties map is copied before being modified because it
+// is shared with the definitions returned by config.GetDefinitions.
+func (o *openAPI) applyDefaultPropertyDescriptions(schema *spec.Schema) {
+	if len(o.config.DefaultPropertyDescriptions) == 0 || len(schema.Properties) == 0 {
+		return
+	}
+	properties := make(map[string]spec.Schema, len(schema.Properties))
+	for name, property := range schema.Properties {
+		if property.Description == "" {
+			if description, ok := o.config.DefaultPropertyDescriptions[name]; ok {
+				property.Description = description
+			}
+		}
+		properties[name] = property
+	}
+	schema.Properties = properties
+}
+
 // buildDefinitionForType build a definition for a given type and return a referable name to its definition.
 // This is the main function that keep track of definitions used in this spec and is depend on code generated
 // by k8s.io/kubernetes/cmd/libs/go2idl/openapi-gen.
@@ -180,6 +494,24 @@ func (o *openAPI) buildDefinitionForType(name string) (string, error) {
 	return "#/definitions/" + common.EscapeJsonPointer(defName), nil
 }
 
+// filterRoutes drops the routes which must not be documented, i.e. the auto-generated OPTIONS routes
+// if the config skips them.
+func (o *openAPI) filterRoutes(routes []restful.Route) []restful.Route {
+	if !o.config.SkipOptions {
+		return routes
+	}
+	filtered := make([]restful.Route, 0, len(routes))
+	for _, route := range routes {
+		if strings.ToUpper(route.Method) == "OPTIONS" {
+			if auto, ok := route.Metadata[common.RouteAutoGeneratedMetadataKey].(bool); ok && auto {
+				continue
+			}
+		}
+		filtered = append(filtered, route)
+	}
+	return filtered
+}
+
 // buildPaths builds OpenAPI paths using go-restful's web services.
 func (o *openAPI) buildPaths(webServices []*restful.WebService) error {
 	pathsToIgnore := util.NewTrie(o.config.IgnorePrefixes)
@@ -193,12 +525,16 @@ func (o *openAPI) buildPaths(webServices []*restful.WebService) error {
 		if err != nil {
 			return err
 		}
-		for path, routes := range groupRoutesByPath(w.Routes()) {
+		for path, routes := range groupRoutesByPath(o.filterRoutes(w.Routes())) {
 			// go-swagger has special variable definition {$NAME:*} that can only be
 			// used at the end of the path and it is not recognized by OpenAPI.
 			if strings.HasSuffix(path, ":*}") {
 				path = path[:len(path)-3] + "}"
 			}
+			// go-restful path parameters may be constrained by a regular expression, e.g.
+			// {id:[0-9]+}, which is documented as the pattern of the parameter.
+			var patterns map[string]string
+			path, patterns = splitPathPatterns(path)
 			if pathsToIgnore.HasPrefix(path) {
 				continue
 			}
@@ -222,12 +558,14 @@ func (o *openAPI) buildPaths(webServices []*restful.WebService) error {
 				pathItem.Parameters = append(pathItem.Parameters, p)
 			}
 			sortParameters(pathItem.Parameters)
+			setPathPatterns(pathItem.Parameters, patterns)
 			for _, route := range routes {
 				op, err := o.buildOperations(route, inPathCommonParamsMap)
 				sortParameters(op.Parameters)
 				if err != nil {
 					return err
 				}
+				setPathPatterns(op.Parameters, patterns)
 				dpath, exists := duplicateOpId[op.ID]
 				if exists {
 					return fmt.Errorf("duplicate Operation ID %v for path %v and %v", op.ID, dpath, path)
@@ -272,6 +610,12 @@ func (o *openAPI) buildOperations(route restful.Route, inPathCommonParamsMap map
 			},
 		},
 	}
+	if o.config.IncludeRouteNotes && route.Notes != "" {
+		if ret.Description != "" {
+			ret.Description += "\n\n"
+		}
+		ret.Description += route.Notes
+	}
 	for k, v := range route.Metadata {
 		if strings.HasPrefix(k, common.ExtensionPrefix) {
 			if ret.Extensions == nil {
@@ -280,23 +624,67 @@ func (o *openAPI) buildOperations(route restful.Route, inPathCommonParamsMap map
 			ret.Extensions.Add(k, v)
 		}
 	}
+	if deprecated, ok := route.Metadata[common.RouteDeprecatedMetadataKey].(bool); ok {
+		ret.Deprecated = deprecated
+	}
+	watch, _ := route.Metadata[common.RouteWatchMetadataKey].(bool)
+	if watch {
+		ret.AddExtension(common.ExtensionAction, "watch")
+		ret.Produces = watchMediaTypes(route.Produces)
+	}
+	if schemes, ok := route.Metadata[common.RouteSchemesMetadataKey].([]string); ok && !equalStrings(schemes, o.config.ProtocolList) {
+		ret.Schemes = schemes
+	}
+	if summary, ok := route.Metadata[common.RouteSummaryMetadataKey].(string); ok {
+		ret.Summary = summary
+	} else if o.config.SummaryFromDescription {
+		ret.Summary = summaryFromDescription(route.Doc)
+	}
 	if ret.ID, ret.Tags, err = o.config.GetOperationIDAndTags(&route); err != nil {
 		return ret, err
 	}
+	if o.config.GetOperationGVK != nil {
+		if gvks := o.config.GetOperationGVK(route.Method, route.Path); gvks != nil {
+			ret.AddExtension(common.ExtensionGroupVersionKind, gvkExtension(gvks))
+		}
+	}
 
-	// Build responses
-	for _, resp := range route.ResponseErrors {
-		ret.Responses.StatusCodeResponses[resp.Code], err = o.buildResponse(resp.Model, resp.Message)
+	// Build responses, in the order of their status codes so that errors are deterministic
+	codes := make([]int, 0, len(route.ResponseErrors))
+	for code := range route.ResponseErrors {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		resp := route.ResponseErrors[code]
+		model := resp.Model
+		if o.config.OmitNoContentResponseSchemas && isNoContentStatus(resp.Code) {
+			model = nil
+		}
+		response, err := o.buildResponse(model, resp.Message)
 		if err != nil {
 			return ret, err
 		}
+		if resp.IsDefault {
+			// declared with DefaultReturns, which has no status code
+			ret.Responses.Default = &response
+			continue
+		}
+		if watch && isSuccessStatus(resp.Code) && response.Schema != nil {
+			response.Schema = watchEventSchema(response.Schema)
+		}
+		ret.Responses.StatusCodeResponses[resp.Code] = response
 	}
 	// If there is no response but a write sample, assume that write sample is an http.StatusOK response.
 	if len(ret.Responses.StatusCodeResponses) == 0 && route.WriteSample != nil {
-		ret.Responses.StatusCodeResponses[http.StatusOK], err = o.buildResponse(route.WriteSample, "OK")
+		response, err := o.buildResponse(route.WriteSample, "OK")
 		if err != nil {
 			return ret, err
 		}
+		if watch && response.Schema != nil {
+			response.Schema = watchEventSchema(response.Schema)
+		}
+		ret.Responses.StatusCodeResponses[http.StatusOK] = response
 	}
 	for code, resp := range o.config.CommonResponses {
 		if _, exists := ret.Responses.StatusCodeResponses[code]; !exists {
@@ -304,9 +692,14 @@ func (o *openAPI) buildOperations(route restful.Route, inPathCommonParamsMap map
 		}
 	}
 	// If there is still no response, use default response provided.
-	if len(ret.Responses.StatusCodeResponses) == 0 {
+	if len(ret.Responses.StatusCodeResponses) == 0 && ret.Responses.Default == nil {
 		ret.Responses.Default = o.config.DefaultResponse
 	}
+	if len(ret.Responses.StatusCodeResponses) == 0 && ret.Responses.Default == nil && o.config.FillEmptyResponses {
+		ret.Responses.StatusCodeResponses[http.StatusOK] = spec.Response{
+			ResponseProps: spec.ResponseProps{Description: "default response"},
+		}
+	}
 
 	// Build non-common Parameters
 	ret.Parameters = make([]spec.Parameter, 0)
@@ -322,7 +715,50 @@ func (o *openAPI) buildOperations(route restful.Route, inPathCommonParamsMap map
 	return ret, nil
 }
 
+// gvkExtension converts group version kinds to the generic JSON representation of extension values.
+func gvkExtension(gvks []map[string]string) []interface{} {
+	ret := make([]interface{}, 0, len(gvks))
+	for _, gvk := range gvks {
+		m := make(map[string]interface{}, len(gvk))
+		for k, v := range gvk {
+			m[k] = v
+		}
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+// summaryFromDescription returns the first line of the description, truncated to maxSummaryLength characters.
+func summaryFromDescription(description string) string {
+	summary := strings.TrimSpace(description)
+	if i := strings.IndexByte(summary, '\n'); i >= 0 {
+		summary = strings.TrimSpace(summary[:i])
+	}
+	if runes := []rune(summary); len(runes) > maxSummaryLength {
+		summary = strings.TrimSpace(string(runes[:maxSummaryLength-3])) + "..."
+	}
+	return summary
+}
+
+// isSuccessStatus returns true for the 2xx status codes.
+func isSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
+
+// isNoContentStatus returns true for the status codes of responses which cannot have a body.
+func isNoContentStatus(code int) bool {
+	return code == http.StatusNoContent || code == http.StatusNotModified
+}
+
+// buildResponse builds a response with the schema of model, or without schema if model is nil.
 func (o *openAPI) buildResponse(model interface{}, description string) (spec.Response, error) {
+	if model == nil {
+		return spec.Response{
+			ResponseProps: spec.ResponseProps{
+				Description: description,
+			},
+		}, nil
+	}
 	schema, err := o.toSchema(util.GetCanonicalTypeName(model))
 	if err != nil {
 		return spec.Response{}, err
@@ -430,9 +866,44 @@ func (o *openAPI) buildParameter(restParam restful.ParameterData, bodySample int
 	ret.Type = openAPIType
 	ret.Format = openAPIFormat
 	ret.UniqueItems = !restParam.AllowMultiple
+	ret.Enum = parameterEnum(restParam.AllowableValues, openAPIType)
 	return ret, nil
 }
 
+// parameterEnum returns the allowable values of a go-restful parameter, i.e. the keys of the map, sorted and
+// converted to the type of the parameter when possible.
+func parameterEnum(allowableValues map[string]string, openAPIType string) []interface{} {
+	if len(allowableValues) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(allowableValues))
+	for v := range allowableValues {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	enum := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		var value interface{} = v
+		switch openAPIType {
+		case "integer":
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				value = i
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				value = f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(v); err == nil {
+				value = b
+			}
+		}
+		enum = append(enum, value)
+	}
+	return enum
+}
+
 func (o *openAPI) buildParameters(restParam []*restful.Parameter) (ret []spec.Parameter, err error) {
 	ret = make([]spec.Parameter, len(restParam))
 	for i, v := range restParam {
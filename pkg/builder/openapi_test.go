@@ -529,3 +529,1101 @@ func TestBuildOpenAPIDefinitionsForResourceWithExtensionV2Schema(t *testing.T) {
 	}
 	assert.Equal(string(expected_json), string(actual_json))
 }
+
+func TestBuildOpenAPIDefinitionsForResourceWithDefaultPropertyDescriptions(t *testing.T) {
+	config, _, assert := setUp(t, true)
+	config.DefaultPropertyDescriptions = map[string]string{
+		"name": "Default name description",
+		"tags": "Default tags description",
+	}
+	expected := getTestInputDefinition()
+	tags := expected.Properties["tags"]
+	tags.Description = "Default tags description"
+	expected.Properties["tags"] = tags
+
+	definitions, err := BuildOpenAPIDefinitionsForResource(TestInput{}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(expected, (*definitions)["builder.TestInput"])
+}
+
+func TestBuildOpenAPIDefinitionsWithRequiredByDefault(t *testing.T) {
+	for _, test := range []struct {
+		requiredByDefault bool
+		expected          []string
+	}{
+		{true, []string{"explicit", "value", "slice"}},
+		{false, []string{"explicit"}},
+	} {
+		t.Run(fmt.Sprintf("%v", test.requiredByDefault), func(t *testing.T) {
+			config, _, assert := setUp(t, false)
+			config.GetDefinitionName = nil
+			config.RequiredByDefault = test.requiredByDefault
+			// generated with --required-by-default=true, which listed value as required but not slice
+			definition := openapi.OpenAPIDefinition{
+				Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"explicit": *spec.StringProperty(),
+						"value":    *spec.StringProperty(),
+						"slice":    *spec.ArrayProperty(spec.StringProperty()),
+						"pointer":  *spec.StringProperty(),
+					},
+					Required: []string{"explicit", "value"},
+				}},
+				RequiredByDefault: []string{"value", "slice"},
+			}
+			config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+				return map[string]openapi.OpenAPIDefinition{"example.com/v1.Foo": definition}
+			}
+
+			swagger, err := BuildOpenAPIDefinitionsForResources(config, "example.com/v1.Foo")
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(test.expected, swagger.Definitions["v1.Foo"].Required)
+			// the definition returned by GetDefinitions is left untouched
+			assert.Equal([]string{"explicit", "value"}, definition.Schema.Required)
+		})
+	}
+}
+
+func TestBuildOpenAPISpecWithOperationSummary(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.SummaryFromDescription = true
+
+	longDescription := strings.Repeat("a very long description ", 10) + "\nwith a second line"
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/summary").
+		Doc("get with summary").
+		Metadata(openapi.RouteSummaryMetadataKey, "Get foo").
+		Operation("getWithSummary").
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/short").
+		Doc("get with short description\nmore details").
+		Operation("getWithShortDescription").
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/long").
+		Doc(longDescription).
+		Operation("getWithLongDescription").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("Get foo", swagger.Paths.Paths["/foo/summary"].Get.Summary)
+	assert.Equal("get with summary", swagger.Paths.Paths["/foo/summary"].Get.Description)
+	assert.Equal("get with short description", swagger.Paths.Paths["/foo/short"].Get.Summary)
+	summary := swagger.Paths.Paths["/foo/long"].Get.Summary
+	assert.Len(summary, maxSummaryLength)
+	assert.True(strings.HasPrefix(longDescription, strings.TrimSuffix(summary, "...")))
+	assert.Equal(longDescription, swagger.Paths.Paths["/foo/long"].Get.Description)
+
+	// without the flag, only explicit summaries are set
+	config.SummaryFromDescription = false
+	swagger, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("Get foo", swagger.Paths.Paths["/foo/summary"].Get.Summary)
+	assert.Empty(swagger.Paths.Paths["/foo/short"].Get.Summary)
+}
+
+func TestBuildOpenAPIDefinitionsForResourceWithSortedCompositions(t *testing.T) {
+	config, _, assert := setUp(t, true)
+	build := func(refs ...string) []spec.Schema {
+		allOf := []spec.Schema{{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}}
+		for _, ref := range refs {
+			allOf = append(allOf, *spec.RefSchema(ref))
+		}
+		definition := *TestInput{}.OpenAPIDefinition()
+		definition.Schema.AllOf = allOf
+		config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+			return map[string]openapi.OpenAPIDefinition{
+				"k8s.io/kube-openapi/pkg/builder.TestInput":                 definition,
+				"k8s.io/kube-openapi/pkg/builder/go_default_test.TestInput": definition,
+			}
+		}
+		definitions, err := BuildOpenAPIDefinitionsForResource(TestInput{}, config)
+		if !assert.NoError(err) {
+			return nil
+		}
+		// the input is not modified
+		assert.Equal(refs[0], allOf[1].Ref.String())
+		return (*definitions)["builder.TestInput"].AllOf
+	}
+
+	first := build("#/definitions/c", "#/definitions/a", "#/definitions/b")
+	second := build("#/definitions/b", "#/definitions/c", "#/definitions/a")
+	assert.Equal(first, second)
+	if assert.Len(first, 4) {
+		assert.Equal("#/definitions/a", first[0].Ref.String())
+		assert.Equal("#/definitions/b", first[1].Ref.String())
+		assert.Equal("#/definitions/c", first[2].Ref.String())
+		assert.Equal(spec.StringOrArray{"object"}, first[3].Type)
+	}
+}
+
+func TestBuildOpenAPISpecWithNoContentResponses(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.OmitNoContentResponseSchemas = true
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.DELETE("/test").
+		Doc("delete test").
+		Operation("deleteTest").
+		Returns(http.StatusNoContent, "No Content", TestOutput{}).
+		Returns(http.StatusNotModified, "Not Modified", nil).
+		Returns(http.StatusOK, "OK", TestInput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	responses := swagger.Paths.Paths["/foo/test"].Delete.Responses.StatusCodeResponses
+	assert.Equal("No Content", responses[http.StatusNoContent].Description)
+	assert.Nil(responses[http.StatusNoContent].Schema)
+	assert.Equal("Not Modified", responses[http.StatusNotModified].Description)
+	assert.Nil(responses[http.StatusNotModified].Schema)
+	if assert.NotNil(responses[http.StatusOK].Schema) {
+		assert.Equal("#/definitions/builder.TestInput", responses[http.StatusOK].Schema.Ref.String())
+	}
+	assert.Contains(swagger.Definitions, "builder.TestInput")
+	assert.NotContains(swagger.Definitions, "builder.TestOutput")
+}
+
+func TestBuildOpenAPISpecWithFillEmptyResponses(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.POST("/nothing").
+		Operation("postNothing").
+		To(noOp))
+	ws.Route(ws.POST("/created").
+		Operation("postCreated").
+		Returns(http.StatusCreated, "Created", nil).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Empty(swagger.Paths.Paths["/foo/nothing"].Post.Responses.StatusCodeResponses)
+
+	config.FillEmptyResponses = true
+	swagger, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(map[int]spec.Response{
+		http.StatusOK: {ResponseProps: spec.ResponseProps{Description: "default response"}},
+	}, swagger.Paths.Paths["/foo/nothing"].Post.Responses.StatusCodeResponses)
+	assert.Nil(swagger.Paths.Paths["/foo/nothing"].Post.Responses.Default)
+	created := swagger.Paths.Paths["/foo/created"].Post.Responses.StatusCodeResponses
+	assert.Len(created, 1)
+	assert.Equal("Created", created[http.StatusCreated].Description)
+
+	// the default response of the config wins
+	config.DefaultResponse = &spec.Response{ResponseProps: spec.ResponseProps{Description: "Default"}}
+	swagger, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Empty(swagger.Paths.Paths["/foo/nothing"].Post.Responses.StatusCodeResponses)
+	assert.Equal(config.DefaultResponse, swagger.Paths.Paths["/foo/nothing"].Post.Responses.Default)
+}
+
+func TestBuildOpenAPIDefinitionsForResourceWithSelectableFields(t *testing.T) {
+	config, _, assert := setUp(t, true)
+	config.GetSelectableFields = func(defName string) []string {
+		if defName == "builder.TestInput" {
+			return []string{"name", "id"}
+		}
+		return nil
+	}
+	definitions, err := BuildOpenAPIDefinitionsForResource(TestInput{}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	def := (*definitions)["builder.TestInput"]
+	assert.Equal([]string{"name", "id"}, def.Extensions[openapi.ExtensionSelectableFields])
+	actual, err := json.Marshal(def.Extensions)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.JSONEq(`{"x-kubernetes-selectable-fields": ["name", "id"], "x-test": "test", "x-test2": "test2"}`, string(actual))
+
+	definitions, err = BuildOpenAPIDefinitionsForResource(TestOutput{}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NotContains((*definitions)["builder.TestOutput"].Extensions, openapi.ExtensionSelectableFields)
+}
+
+func TestBuildOpenAPISpecWithInfoVersionFunc(t *testing.T) {
+	config, container, assert := setUp(t, false)
+	version := 0
+	config.InfoVersionFunc = func() string {
+		version++
+		return fmt.Sprintf("v0.0.%d", version)
+	}
+
+	swagger, err := BuildOpenAPISpec(container.RegisteredWebServices(), config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("v0.0.1", swagger.Info.Version)
+	assert.Equal("TestAPI", swagger.Info.Title)
+
+	swagger, err = BuildOpenAPISpec(container.RegisteredWebServices(), config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("v0.0.2", swagger.Info.Version)
+	// the static info is not modified
+	assert.Equal("unversioned", config.Info.Version)
+}
+
+func TestBuildOpenAPISpecWithSkipOptions(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.SkipOptions = true
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/auto").
+		Doc("get auto").
+		Operation("getAuto").
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.Method("OPTIONS").Path("/auto").
+		Doc("preflight").
+		Metadata(openapi.RouteAutoGeneratedMetadataKey, true).
+		Operation("optionsAuto").
+		To(noOp))
+	ws.Route(ws.Method("OPTIONS").Path("/explicit").
+		Doc("explicit options").
+		Operation("optionsExplicit").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NotNil(swagger.Paths.Paths["/foo/auto"].Get)
+	assert.Nil(swagger.Paths.Paths["/foo/auto"].Options)
+	if assert.NotNil(swagger.Paths.Paths["/foo/explicit"].Options) {
+		assert.Equal("optionsExplicit", swagger.Paths.Paths["/foo/explicit"].Options.ID)
+	}
+
+	// without the flag, auto-generated OPTIONS operations are documented
+	config.SkipOptions = false
+	swagger, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NotNil(swagger.Paths.Paths["/foo/auto"].Options)
+}
+
+func TestBuildOpenAPISpecWithPathPatterns(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/items/{id:[0-9]{1,8}}/{file:*}").
+		Param(ws.PathParameter("id", "id of the item")).
+		Param(ws.PathParameter("file", "path of the file")).
+		Operation("getItemFile").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	pathItem, ok := swagger.Paths.Paths["/foo/items/{id}/{file}"]
+	if !assert.True(ok, "clean path template expected, got %v", swagger.Paths.Paths) {
+		return
+	}
+	params := map[string]spec.Parameter{}
+	for _, p := range pathItem.Parameters {
+		params[p.Name] = p
+	}
+	for _, p := range pathItem.Get.Parameters {
+		params[p.Name] = p
+	}
+	assert.Equal("[0-9]{1,8}", params["id"].Pattern)
+	assert.Equal("", params["file"].Pattern)
+}
+
+func TestBuildOpenAPISpecWithMultipleResponses(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	route := ws.POST("/items").
+		Operation("createItem").
+		Reads(TestInput{}).
+		Returns(200, "OK", TestOutput{}).
+		Returns(201, "Created", TestOutput{}).
+		Returns(404, "Not Found", nil).
+		DefaultReturns("Error", TestOutput{}).
+		To(noOp).
+		Build()
+
+	o := newOpenAPI(config)
+	op, err := o.buildOperations(route, nil)
+	if !assert.NoError(err) {
+		return
+	}
+	responses := op.Responses
+	if assert.Len(responses.StatusCodeResponses, 3) {
+		assert.Equal("OK", responses.StatusCodeResponses[200].Description)
+		assert.Equal("#/definitions/builder.TestOutput", responses.StatusCodeResponses[200].Schema.Ref.String())
+		assert.Equal("Created", responses.StatusCodeResponses[201].Description)
+		assert.Equal("#/definitions/builder.TestOutput", responses.StatusCodeResponses[201].Schema.Ref.String())
+		assert.Equal("Not Found", responses.StatusCodeResponses[404].Description)
+		assert.Nil(responses.StatusCodeResponses[404].Schema)
+	}
+	if assert.NotNil(responses.Default) {
+		assert.Equal("Error", responses.Default.Description)
+		assert.Equal("#/definitions/builder.TestOutput", responses.Default.Schema.Ref.String())
+	}
+}
+
+func TestBuildOpenAPISpecWithDeprecatedRoutes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/old").
+		Operation("getOld").
+		Metadata(openapi.RouteDeprecatedMetadataKey, true).
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/new").
+		Operation("getNew").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.True(swagger.Paths.Paths["/foo/old"].Get.Deprecated)
+	assert.False(swagger.Paths.Paths["/foo/new"].Get.Deprecated)
+}
+
+func TestBuildOpenAPISpecWithOperationSchemes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/watch").
+		Operation("watchFoo").
+		Metadata(openapi.RouteSchemesMetadataKey, []string{"wss"}).
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/list").
+		Operation("listFoo").
+		Metadata(openapi.RouteSchemesMetadataKey, []string{"https"}).
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/get").
+		Operation("getFoo").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal([]string{"wss"}, swagger.Paths.Paths["/foo/watch"].Get.Schemes)
+	assert.Equal([]string{"https"}, swagger.Paths.Paths["/foo/list"].Get.Schemes)
+	assert.Equal([]string{"https"}, swagger.Paths.Paths["/foo/get"].Get.Schemes)
+}
+
+func TestBuildOpenAPISpecWithWatchRoutes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Produces("application/json", "application/yaml")
+	ws.Route(ws.GET("/watch").
+		Operation("watchFoo").
+		Metadata(openapi.RouteWatchMetadataKey, true).
+		Returns(200, "OK", TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/list").
+		Operation("listFoo").
+		Returns(200, "OK", TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	watch := swagger.Paths.Paths["/foo/watch"].Get
+	assert.Equal("watch", watch.Extensions[openapi.ExtensionAction])
+	assert.Equal([]string{"application/json", "application/yaml", "application/json;stream=watch", "application/yaml;stream=watch"}, watch.Produces)
+	// each line of the stream is an event about an object of the returned type
+	event := watch.Responses.StatusCodeResponses[200].Schema
+	if assert.NotNil(event) {
+		assert.Equal(spec.StringOrArray{"object"}, event.Type)
+		assert.Equal([]string{"type", "object"}, event.Required)
+		assert.Equal(spec.StringOrArray{"string"}, event.Properties["type"].Type)
+		object := event.Properties["object"]
+		assert.Equal("#/definitions/builder.TestOutput", object.Ref.String())
+	}
+
+	list := swagger.Paths.Paths["/foo/list"].Get
+	assert.NotContains(list.Extensions, openapi.ExtensionAction)
+	assert.Equal("#/definitions/builder.TestOutput", list.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	assert.Equal([]string{"application/json", "application/yaml"}, list.Produces)
+}
+
+func TestBuildOpenAPISpecWithPostProcessSchema(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.PostProcessSchema = func(name string, schema *spec.Schema) error {
+		if name == "builder.TestOutput" {
+			schema.AddExtension("x-kubernetes-test", "output")
+			schema.Description = "Processed " + schema.Description
+		}
+		return nil
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.POST("/bar").
+		Operation("postBar").
+		Reads(TestInput{}).
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	output := swagger.Definitions["builder.TestOutput"]
+	assert.Equal("output", output.Extensions["x-kubernetes-test"])
+	assert.Equal("test2", output.Extensions["x-test2"])
+	assert.Equal("Processed Test output", output.Description)
+	assert.NotContains(swagger.Definitions["builder.TestInput"].Extensions, "x-kubernetes-test")
+
+	config.PostProcessSchema = func(name string, schema *spec.Schema) error {
+		return fmt.Errorf("broken")
+	}
+	_, err = BuildOpenAPIDefinitionsForResource(TestOutput{}, config)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "broken")
+	}
+}
+
+func TestBuildOpenAPISpecWithParameterEnums(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.POST("/items/{kind}").
+		Operation("postItems").
+		Param(ws.PathParameter("kind", "kind of item").AllowableValues(map[string]string{"small": "", "large": ""})).
+		Param(ws.QueryParameter("sort", "sort order").AllowableValues(map[string]string{"desc": "descending", "asc": "ascending"})).
+		Param(ws.HeaderParameter("X-Priority", "priority").DataType("integer").AllowableValues(map[string]string{"2": "", "1": "", "10": ""})).
+		Param(ws.FormParameter("dryRun", "dry run").DataType("boolean").AllowableValues(map[string]string{"true": "", "false": ""})).
+		Param(ws.QueryParameter("limit", "maximum number of items")).
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	pathItem := swagger.Paths.Paths["/foo/items/{kind}"]
+	enums := map[string][]interface{}{}
+	for _, params := range [][]spec.Parameter{pathItem.Parameters, pathItem.Post.Parameters} {
+		for _, p := range params {
+			enums[p.Name] = p.Enum
+		}
+	}
+	assert.Equal([]interface{}{"large", "small"}, enums["kind"])
+	assert.Equal([]interface{}{"asc", "desc"}, enums["sort"])
+	assert.Equal([]interface{}{int64(1), int64(10), int64(2)}, enums["X-Priority"])
+	assert.Equal([]interface{}{false, true}, enums["dryRun"])
+	assert.Nil(enums["limit"])
+}
+
+func TestBuildOpenAPISpecWithHostAndBasePath(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.Host = "api.example.com:6443"
+	config.BasePath = "/apis"
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/bar").
+		Operation("getBar").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("api.example.com:6443", swagger.Host)
+	assert.Equal("/apis", swagger.BasePath)
+
+	config.BasePath = "apis"
+	_, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	assert.Error(err)
+}
+
+func TestBuildOpenAPISpecWithOperationGVK(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.GetOperationGVK = func(method, path string) []map[string]string {
+		if method == "GET" && path == "/foo/pods/{name}" {
+			return []map[string]string{{"group": "", "version": "v1", "kind": "Pod"}}
+		}
+		return nil
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/pods/{name}").
+		Param(ws.PathParameter("name", "name of the pod")).
+		Operation("readPod").
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/healthz").
+		Operation("healthz").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal([]interface{}{
+		map[string]interface{}{"group": "", "version": "v1", "kind": "Pod"},
+	}, swagger.Paths.Paths["/foo/pods/{name}"].Get.Extensions[openapi.ExtensionGroupVersionKind])
+	_, ok := swagger.Paths.Paths["/foo/healthz"].Get.Extensions[openapi.ExtensionGroupVersionKind]
+	assert.False(ok)
+}
+
+func TestBuildOpenAPISpecWithWildcardMIMETypes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.POST("/upload").
+		Consumes("*/*").
+		Produces("application/*", "application/json").
+		Operation("upload").
+		Reads(TestInput{}).
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	op := swagger.Paths.Paths["/foo/upload"].Post
+	assert.Equal([]string{"*/*"}, op.Consumes)
+	assert.Equal([]string{"application/*", "application/json"}, op.Produces)
+}
+
+func TestBuildOpenAPIDefinitionsWithExpectedListTypes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.GetDefinitionName = func(name string) (string, spec.Extensions) {
+		return name, nil
+	}
+	listType := func(t string) spec.Schema {
+		s := spec.ArrayProperty(spec.StringProperty())
+		if t != "" {
+			s.AddExtension(openapi.ExtensionListType, t)
+		}
+		return *s
+	}
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		return map[string]openapi.OpenAPIDefinition{
+			"Pod": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"finalizers": listType("set"),
+					"args":       listType(""),
+				},
+			}}},
+			"Job": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"finalizers": listType("atomic"),
+				},
+			}}},
+		}
+	}
+	config.ExpectedListTypes = map[string]string{"finalizers": "set", "args": "atomic"}
+
+	_, err := BuildOpenAPIDefinitionsForResources(config, "Pod")
+	assert.NoError(err)
+
+	_, err = BuildOpenAPIDefinitionsForResources(config, "Pod", "Job")
+	if assert.Error(err) {
+		assert.Equal("unexpected list types: Job.finalizers is atomic, expected set", err.Error())
+	}
+}
+
+func TestBuildOpenAPIDefinitionsWithHoistedInlineSchemas(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.GetDefinitionName = func(name string) (string, spec.Extensions) {
+		return name, nil
+	}
+	object := func(properties ...string) spec.Schema {
+		s := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: map[string]spec.Schema{}}}
+		for _, p := range properties {
+			s.Properties[p] = *spec.StringProperty()
+		}
+		return s
+	}
+	address := object("street", "city", "zip")
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		return map[string]openapi.OpenAPIDefinition{
+			"Person": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"home":  address,
+					"extra": object("a"),
+				},
+			}}},
+			"Company": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"offices": *spec.ArrayProperty(&address),
+					"extra":   object("b"),
+				},
+			}}},
+		}
+	}
+	config.HoistInlineSchemasMinSize = 50
+
+	swagger, err := BuildOpenAPIDefinitionsForResources(config, "Person", "Company")
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Len(swagger.Definitions, 3)
+	home := swagger.Definitions["Person"].Properties["home"]
+	name, ok := definitionRefName(home.Ref)
+	if assert.True(ok, "home is not a reference") {
+		assert.True(strings.HasPrefix(name, "inline."))
+		assert.Equal(address, swagger.Definitions[name])
+	}
+	assert.Equal(home.Ref, swagger.Definitions["Company"].Properties["offices"].Items.Schema.Ref)
+	// distinct schemas are kept inline
+	assert.Equal(object("a"), swagger.Definitions["Person"].Properties["extra"])
+	assert.Equal(object("b"), swagger.Definitions["Company"].Properties["extra"])
+
+	// the same spec is built again
+	again, err := BuildOpenAPIDefinitionsForResources(config, "Person", "Company")
+	if assert.NoError(err) {
+		assert.Equal(swagger.Definitions, again.Definitions)
+	}
+
+	// schemas smaller than the minimum size are kept inline
+	config.HoistInlineSchemasMinSize = 1000
+	swagger, err = BuildOpenAPIDefinitionsForResources(config, "Person", "Company")
+	if assert.NoError(err) {
+		assert.Len(swagger.Definitions, 2)
+		assert.Equal(address, swagger.Definitions["Person"].Properties["home"])
+	}
+}
+
+func TestBuildOpenAPIDefinitionsWithExcludedDefinitions(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.GetDefinitionName = func(name string) (string, spec.Extensions) {
+		return name, nil
+	}
+	config.IncludeDefinition = func(name string) bool {
+		return name != "AlphaFeature"
+	}
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		return map[string]openapi.OpenAPIDefinition{
+			"AlphaFeature": {Schema: spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}},
+			"Stable": {
+				Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+					Type:     []string{"object"},
+					Required: []string{"name", "alpha"},
+					Properties: map[string]spec.Schema{
+						"name":   *spec.StringProperty(),
+						"alpha":  *spec.RefSchema("#/definitions/AlphaFeature"),
+						"alphas": *spec.ArrayProperty(spec.RefSchema("#/definitions/AlphaFeature")),
+					},
+				}},
+				Dependencies: []string{"AlphaFeature"},
+			},
+		}
+	}
+
+	swagger, err := BuildOpenAPIDefinitionsForResources(config, "Stable")
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NotContains(swagger.Definitions, "AlphaFeature")
+	stable := swagger.Definitions["Stable"]
+	assert.Equal(map[string]spec.Schema{"name": *spec.StringProperty()}, stable.Properties)
+	assert.Equal([]string{"name"}, stable.Required)
+
+	_, err = BuildOpenAPIDefinitionsForResources(config, "AlphaFeature")
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "AlphaFeature is excluded")
+	}
+
+	// routes cannot reference excluded definitions
+	config, _, _ = setUp(t, false)
+	config.IncludeDefinition = func(name string) bool {
+		return name != "builder.TestOutput"
+	}
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/bar").
+		Operation("getBar").
+		Writes(TestOutput{}).
+		To(noOp))
+	_, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "builder.TestOutput is excluded")
+	}
+}
+
+func TestBuildDefinitionsByPackage(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	// reverse the domain of the package paths, e.g. com.example.apps.v1.Spec for example.com/apps/v1.Spec
+	config.GetDefinitionName = func(name string) (string, spec.Extensions) {
+		parts := strings.Split(name, "/")
+		domain := strings.Split(parts[0], ".")
+		for i, j := 0, len(domain)-1; i < j; i, j = i+1, j-1 {
+			domain[i], domain[j] = domain[j], domain[i]
+		}
+		return strings.Join(append(domain, parts[1:]...), "."), nil
+	}
+	getDefinitionsCalls := 0
+	object := func(refs ...string) spec.Schema {
+		schema := spec.Schema{SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{"name": *spec.StringProperty()},
+		}}
+		for _, ref := range refs {
+			schema.Properties[ref] = *spec.RefSchema("#/definitions/" + ref)
+		}
+		return schema
+	}
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		getDefinitionsCalls++
+		return map[string]openapi.OpenAPIDefinition{
+			"example.com/apps/v1.Deployment": {Schema: object("com.example.apps.v1.Spec"), Dependencies: []string{"example.com/apps/v1.Spec"}},
+			"example.com/apps/v1.Spec":       {Schema: object("com.example.core.v1.Spec"), Dependencies: []string{"example.com/core/v1.Spec"}},
+			"example.com/core/v1.Pod":        {Schema: object("com.example.core.v1.Spec"), Dependencies: []string{"example.com/core/v1.Spec"}},
+			"example.com/core/v1.Spec":       {Schema: object()},
+		}
+	}
+
+	groups, err := BuildDefinitionsByPackage(config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(1, getDefinitionsCalls)
+	assert.Len(groups, 2)
+	// both packages have a Spec type, whose definitions do not collide
+	assert.Equal(spec.Definitions{
+		"com.example.apps.v1.Deployment": object("com.example.apps.v1.Spec"),
+		"com.example.apps.v1.Spec":       object("com.example.core.v1.Spec"),
+		"com.example.core.v1.Spec":       object(),
+	}, groups["com.example.apps.v1"])
+	assert.Equal(spec.Definitions{
+		"com.example.core.v1.Pod":  object("com.example.core.v1.Spec"),
+		"com.example.core.v1.Spec": object(),
+	}, groups["com.example.core.v1"])
+}
+
+func TestBuildOpenAPISpecWithCollidingDefinitionNames(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.GetDefinitionName = nil
+	object := func(refs ...string) spec.Schema {
+		schema := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: map[string]spec.Schema{}}}
+		for _, ref := range refs {
+			schema.Properties[ref] = *spec.RefSchema("#/definitions/" + ref)
+		}
+		return schema
+	}
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		return map[string]openapi.OpenAPIDefinition{
+			"example.com/a/foo.Widget": {Schema: object()},
+			"example.com/b/foo.Widget": {Schema: object()},
+			"example.com/c/bar.Gadget": {Schema: object("foo.Widget"), Dependencies: []string{"example.com/b/foo.Widget"}},
+		}
+	}
+
+	_, err := BuildOpenAPIDefinitionsForResources(config, "example.com/a/foo.Widget", "example.com/c/bar.Gadget")
+	assert.EqualError(err, "definition name foo.Widget is used by both example.com/a/foo.Widget and example.com/b/foo.Widget, GetDefinitionName must return unique names")
+
+	// a type referenced several times is not a collision
+	swagger, err := BuildOpenAPIDefinitionsForResources(config, "example.com/b/foo.Widget", "example.com/c/bar.Gadget")
+	if assert.NoError(err) {
+		assert.Len(swagger.Definitions, 2)
+	}
+}
+
+func TestBuildOpenAPIDefinitionsForResourcesWithInlinedArrayItems(t *testing.T) {
+	config, _, assert := setUp(t, true)
+	config.InlineArrayItemRefs = true
+	config.GetDefinitionName = func(name string) (string, spec.Extensions) {
+		return name, nil
+	}
+	arrayOf := func(name string) spec.Schema {
+		return *spec.ArrayProperty(spec.RefSchema("#/definitions/" + name))
+	}
+	tag := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{"name": *spec.StringProperty()},
+	}}
+	node := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{"children": arrayOf("Node")},
+	}}
+	config.GetDefinitions = func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+		return map[string]openapi.OpenAPIDefinition{
+			"Tag":  {Schema: tag},
+			"Node": {Schema: node, Dependencies: []string{"Node"}},
+			"Parent": {
+				Schema: spec.Schema{SchemaProps: spec.SchemaProps{
+					Type: []string{"object"},
+					Properties: map[string]spec.Schema{
+						"tags":  arrayOf("Tag"),
+						"nodes": arrayOf("Node"),
+					},
+				}},
+				Dependencies: []string{"Tag", "Node"},
+			},
+		}
+	}
+
+	swagger, err := BuildOpenAPIDefinitionsForResources(config, "Parent")
+	if !assert.NoError(err) {
+		return
+	}
+	parent := swagger.Definitions["Parent"]
+	assert.Equal(&tag, parent.Properties["tags"].Items.Schema)
+
+	// recursive types are inlined once, then referenced
+	inlinedNode := parent.Properties["nodes"].Items.Schema
+	assert.Equal("#/definitions/Node", inlinedNode.Properties["children"].Items.Schema.Ref.String())
+	assert.Equal(node, swagger.Definitions["Node"])
+
+	// the definitions provided by the config are not modified
+	assert.Equal("#/definitions/Node", node.Properties["children"].Items.Schema.Ref.String())
+
+	config.InlineArrayItemRefs = false
+	swagger, err = BuildOpenAPIDefinitionsForResources(config, "Parent")
+	if !assert.NoError(err) {
+		return
+	}
+	parent = swagger.Definitions["Parent"]
+	assert.Equal("#/definitions/Tag", parent.Properties["tags"].Items.Schema.Ref.String())
+}
+
+func TestBuildOpenAPISpecWithRouteNotes(t *testing.T) {
+	config, _, assert := setUp(t, false)
+	config.IncludeRouteNotes = true
+	config.SummaryFromDescription = true
+
+	ws := new(restful.WebService)
+	ws.Path("/foo")
+	ws.Route(ws.GET("/notes").
+		Doc("get with notes").
+		Notes("Some extended documentation.").
+		Operation("getWithNotes").
+		Writes(TestOutput{}).
+		To(noOp))
+	ws.Route(ws.GET("/only-notes").
+		Notes("Only notes.").
+		Operation("getWithOnlyNotes").
+		Writes(TestOutput{}).
+		To(noOp))
+
+	swagger, err := BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	op := swagger.Paths.Paths["/foo/notes"].Get
+	assert.Equal("get with notes\n\nSome extended documentation.", op.Description)
+	assert.Equal("get with notes", op.Summary)
+	assert.Equal("Only notes.", swagger.Paths.Paths["/foo/only-notes"].Get.Description)
+
+	// without the flag, notes are ignored
+	config.IncludeRouteNotes = false
+	swagger, err = BuildOpenAPISpec([]*restful.WebService{ws}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal("get with notes", swagger.Paths.Paths["/foo/notes"].Get.Description)
+}
+
+func TestBuildOpenAPIDefinitionsForResourceWithUnions(t *testing.T) {
+	config, _, assert := setUp(t, true)
+	union := map[string]interface{}{
+		"discriminator": "type",
+		"fields-to-discriminateBy": map[string]interface{}{
+			"name": "Name",
+			"id":   "ID",
+		},
+	}
+	config.GetUnions = func(defName string) []interface{} {
+		if defName == "builder.TestInput" {
+			return []interface{}{union}
+		}
+		return nil
+	}
+	definitions, err := BuildOpenAPIDefinitionsForResource(TestInput{}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	def := (*definitions)["builder.TestInput"]
+	assert.Equal([]interface{}{union}, def.Extensions[openapi.ExtensionUnions])
+	assert.Equal("test", def.Extensions["x-test"])
+
+	definitions, err = BuildOpenAPIDefinitionsForResource(TestOutput{}, config)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NotContains((*definitions)["builder.TestOutput"].Extensions, openapi.ExtensionUnions)
+}
+
+// getSyntheticDefinitions returns n object definitions named "example.com/v1.TypeN", each referencing
+// the next one, and the config building them with the given PostProcessSchema.
+func getSyntheticDefinitions(n int, postProcessSchema func(name string, schema *spec.Schema) error) (*openapi.Config, []string) {
+	names := make([]string, n)
+	definitions := make(map[string]openapi.OpenAPIDefinition, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("example.com/v1.Type%d", i)
+		schema := spec.Schema{SchemaProps: spec.SchemaProps{
+			Type:        []string{"object"},
+			Description: fmt.Sprintf("Type%d is a synthetic type", i),
+			Properties: map[string]spec.Schema{
+				"name":  *spec.StringProperty(),
+				"count": *spec.Int64Property(),
+			},
+		}}
+		var dependencies []string
+		if i+1 < n {
+			schema.Properties["next"] = *spec.RefSchema(fmt.Sprintf("#/definitions/v1.Type%d", i+1))
+			dependencies = append(dependencies, fmt.Sprintf("example.com/v1.Type%d", i+1))
+		}
+		definitions[names[i]] = openapi.OpenAPIDefinition{Schema: schema, Dependencies: dependencies}
+	}
+	return &openapi.Config{
+		GetDefinitions: func(_ openapi.ReferenceCallback) map[string]openapi.OpenAPIDefinition {
+			return definitions
+		},
+		DefaultPropertyDescriptions: map[string]string{"name": "Name of the object."},
+		PostProcessSchema:           postProcessSchema,
+	}, names
+}
+
+func TestBuildOpenAPIDefinitionsConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	postProcessSchema := func(name string, schema *spec.Schema) error {
+		schema.AddExtension("x-kubernetes-name", name)
+		return nil
+	}
+
+	config, names := getSyntheticDefinitions(100, postProcessSchema)
+	expected, err := BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Len(expected.Definitions, 100)
+
+	for _, concurrency := range []int{0, 1, 4} {
+		config, names := getSyntheticDefinitions(100, postProcessSchema)
+		config.BuildDefinitionsConcurrently = true
+		config.DefinitionsConcurrency = concurrency
+		swagger, err := BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+		if !assert.NoError(err) {
+			return
+		}
+		assert.Equal(expected, swagger, "concurrency %d", concurrency)
+	}
+
+	// the error of the first definition referenced is returned, whichever fails first
+	config, names = getSyntheticDefinitions(100, func(name string, schema *spec.Schema) error {
+		if name == "v1.Type10" || name == "v1.Type90" {
+			return fmt.Errorf("broken %s", name)
+		}
+		return nil
+	})
+	config.BuildDefinitionsConcurrently = true
+	config.DefinitionsConcurrency = 8
+	_, err = BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+	assert.EqualError(err, "failed to post-process definition v1.Type10: broken v1.Type10")
+}
+
+func TestBuildOpenAPIDefinitionsConcurrentlyPrunedAndChecked(t *testing.T) {
+	assert := assert.New(t)
+	newConfig := func(concurrently bool) (*openapi.Config, []string) {
+		config, names := getSyntheticDefinitions(100, func(name string, schema *spec.Schema) error {
+			if name == "v1.Type3" || name == "v1.Type12" {
+				properties := map[string]spec.Schema{"items": *spec.ArrayProperty(spec.StringProperty())}
+				for k, v := range schema.Properties {
+					properties[k] = v
+				}
+				schema.Properties = properties
+			}
+			return nil
+		})
+		config.IncludeDefinition = func(name string) bool { return name != "v1.Type50" }
+		config.BuildDefinitionsConcurrently = concurrently
+		config.DefinitionsConcurrency = 4
+		return config, names
+	}
+
+	config, names := newConfig(false)
+	expected, err := BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Len(expected.Definitions, 50)
+	assert.NotContains(expected.Definitions["v1.Type49"].Properties, "next")
+	config, names = newConfig(true)
+	swagger, err := BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+	if assert.NoError(err) {
+		assert.Equal(expected, swagger)
+	}
+
+	for _, concurrently := range []bool{false, true} {
+		config, names := newConfig(concurrently)
+		config.ExpectedListTypes = map[string]string{"items": "map"}
+		_, err := BuildOpenAPIDefinitionsForResources(config, names[:1]...)
+		assert.EqualError(err, "unexpected list types: v1.Type12.items is atomic, expected map; v1.Type3.items is atomic, expected map", "concurrently %v", concurrently)
+	}
+}
+
+// BenchmarkBuildOpenAPIDefinitions measures the default code path, without PostProcessSchema. The pool
+// only pays off with several CPUs; on a single one it is slower than building the definitions serially.
+func BenchmarkBuildOpenAPIDefinitions(b *testing.B) {
+	for _, concurrently := range []bool{false, true} {
+		b.Run(fmt.Sprintf("concurrently=%v", concurrently), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				config, names := getSyntheticDefinitions(1000, nil)
+				config.BuildDefinitionsConcurrently = concurrently
+				if _, err := BuildOpenAPIDefinitionsForResources(config, names...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
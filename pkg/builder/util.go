@@ -17,9 +17,14 @@ limitations under the License.
 package builder
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/emicklei/go-restful"
+	"k8s.io/kube-openapi/pkg/common"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 )
 
@@ -42,6 +47,470 @@ func sortParameters(p []spec.Parameter) {
 	sort.Sort(byNameIn{p})
 }
 
+// byRef used in sorting schemas by $ref, schemas without $ref last.
+type byRef []spec.Schema
+
+func (s byRef) Len() int      { return len(s) }
+func (s byRef) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byRef) Less(i, j int) bool {
+	ri, rj := s[i].Ref.String(), s[j].Ref.String()
+	if ri == "" || rj == "" {
+		return ri != "" && rj == ""
+	}
+	return ri < rj
+}
+
+// sortCompositions sorts the members of the allOf, anyOf and oneOf lists of the schema and of its nested
+// schemas by $ref, so that the output does not depend on the order in which definitions were assembled.
+// Members without $ref are kept in their original order after the referencing ones. The lists are copied
+// before being sorted, the definitions provided by the config are not modified.
+func sortCompositions(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+	schema.AllOf = sortedComposition(schema.AllOf)
+	schema.AnyOf = sortedComposition(schema.AnyOf)
+	schema.OneOf = sortedComposition(schema.OneOf)
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]spec.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			sortCompositions(&property)
+			properties[name] = property
+		}
+		schema.Properties = properties
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		items := *schema.Items
+		itemsSchema := *items.Schema
+		sortCompositions(&itemsSchema)
+		items.Schema = &itemsSchema
+		schema.Items = &items
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		additionalProperties := *schema.AdditionalProperties
+		additionalPropertiesSchema := *additionalProperties.Schema
+		sortCompositions(&additionalPropertiesSchema)
+		additionalProperties.Schema = &additionalPropertiesSchema
+		schema.AdditionalProperties = &additionalProperties
+	}
+}
+
+func sortedComposition(schemas []spec.Schema) []spec.Schema {
+	if len(schemas) == 0 {
+		return schemas
+	}
+	sorted := make([]spec.Schema, len(schemas))
+	for i := range schemas {
+		sorted[i] = schemas[i]
+		sortCompositions(&sorted[i])
+	}
+	sort.Stable(byRef(sorted))
+	return sorted
+}
+
+// inlineArrayItemRefs returns a copy of the definitions in which the items of arrays referencing
+// another definition are replaced by the schema of that definition. The items of an array whose
+// definition is already being inlined, i.e. of a recursive type, keep their $ref.
+func inlineArrayItemRefs(definitions spec.Definitions) spec.Definitions {
+	ret := make(spec.Definitions, len(definitions))
+	for name, schema := range definitions {
+		inlineItemRefs(&schema, definitions, map[string]bool{name: true})
+		ret[name] = schema
+	}
+	return ret
+}
+
+func inlineItemRefs(schema *spec.Schema, definitions spec.Definitions, inlining map[string]bool) {
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]spec.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			inlineItemRefs(&property, definitions, inlining)
+			properties[name] = property
+		}
+		schema.Properties = properties
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		additionalProperties := *schema.AdditionalProperties
+		additionalPropertiesSchema := *additionalProperties.Schema
+		inlineItemRefs(&additionalPropertiesSchema, definitions, inlining)
+		additionalProperties.Schema = &additionalPropertiesSchema
+		schema.AdditionalProperties = &additionalProperties
+	}
+	for _, schemas := range []*[]spec.Schema{&schema.AllOf, &schema.AnyOf, &schema.OneOf} {
+		if len(*schemas) == 0 {
+			continue
+		}
+		inlined := make([]spec.Schema, len(*schemas))
+		for i := range *schemas {
+			inlined[i] = (*schemas)[i]
+			inlineItemRefs(&inlined[i], definitions, inlining)
+		}
+		*schemas = inlined
+	}
+	if schema.Items == nil || schema.Items.Schema == nil {
+		return
+	}
+
+	items := *schema.Items
+	itemsSchema := *items.Schema
+	if name, ok := definitionRefName(itemsSchema.Ref); ok && !inlining[name] {
+		if definition, ok := definitions[name]; ok {
+			itemsSchema = definition
+			inlining[name] = true
+			defer delete(inlining, name)
+		}
+	}
+	inlineItemRefs(&itemsSchema, definitions, inlining)
+	items.Schema = &itemsSchema
+	schema.Items = &items
+}
+
+// hoistedDefinitionPrefix prefixes the names of the definitions created by hoistInlineSchemas.
+const hoistedDefinitionPrefix = "inline."
+
+// hoistInlineSchemas returns a copy of the definitions in which the inline object schemas, i.e. with
+// properties, which occur at least twice and whose JSON encoding is at least minSize bytes long are
+// replaced by a $ref to a new definition holding them. The new definitions are named after a hash of
+// their schema, so that the result is deterministic.
+func hoistInlineSchemas(definitions spec.Definitions, minSize int) spec.Definitions {
+	counts := map[string]int{}
+	candidates := map[string]spec.Schema{}
+	var collect func(schema *spec.Schema)
+	collect = func(schema *spec.Schema) {
+		copySchemaChildren(schema, func(child *spec.Schema) {
+			if child.Ref.String() == "" && len(child.Properties) > 0 {
+				if b, err := json.Marshal(child); err == nil && len(b) >= minSize {
+					counts[string(b)]++
+					candidates[string(b)] = *child
+				}
+			}
+			collect(child)
+		})
+	}
+	for _, schema := range definitions {
+		collect(&schema)
+	}
+
+	hoisted := map[string]string{}
+	for key, count := range counts {
+		if count < 2 {
+			continue
+		}
+		name := fmt.Sprintf("%s%x", hoistedDefinitionPrefix, sha256.Sum256([]byte(key)))[:len(hoistedDefinitionPrefix)+16]
+		if _, found := definitions[name]; !found {
+			hoisted[key] = name
+		}
+	}
+	if len(hoisted) == 0 {
+		return definitions
+	}
+
+	var replace func(schema *spec.Schema)
+	replace = func(schema *spec.Schema) {
+		copySchemaChildren(schema, func(child *spec.Schema) {
+			if child.Ref.String() == "" && len(child.Properties) > 0 {
+				if b, err := json.Marshal(child); err == nil {
+					if name, ok := hoisted[string(b)]; ok {
+						*child = *spec.RefSchema("#/definitions/" + name)
+						return
+					}
+				}
+			}
+			replace(child)
+		})
+	}
+	ret := make(spec.Definitions, len(definitions)+len(hoisted))
+	for name, schema := range definitions {
+		replace(&schema)
+		ret[name] = schema
+	}
+	for key, name := range hoisted {
+		schema := candidates[key]
+		replace(&schema)
+		ret[name] = schema
+	}
+	return ret
+}
+
+// copySchemaChildren replaces the properties, additional properties, items and composition members of
+// the schema by copies, and calls fn with each of the copies.
+func copySchemaChildren(schema *spec.Schema, fn func(child *spec.Schema)) {
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]spec.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			fn(&property)
+			properties[name] = property
+		}
+		schema.Properties = properties
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		additionalProperties := *schema.AdditionalProperties
+		additionalPropertiesSchema := *additionalProperties.Schema
+		fn(&additionalPropertiesSchema)
+		additionalProperties.Schema = &additionalPropertiesSchema
+		schema.AdditionalProperties = &additionalProperties
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		items := *schema.Items
+		itemsSchema := *items.Schema
+		fn(&itemsSchema)
+		items.Schema = &itemsSchema
+		schema.Items = &items
+	}
+	for _, schemas := range []*[]spec.Schema{&schema.AllOf, &schema.AnyOf, &schema.OneOf} {
+		if len(*schemas) == 0 {
+			continue
+		}
+		copied := make([]spec.Schema, len(*schemas))
+		for i := range *schemas {
+			copied[i] = (*schemas)[i]
+			fn(&copied[i])
+		}
+		*schemas = copied
+	}
+}
+
+// pruneExcludedRefs returns a copy of the schema without the properties, items and composition members
+// referencing an excluded definition. It returns false if the schema itself references an excluded
+// definition, directly or through its items or additional properties.
+func pruneExcludedRefs(schema spec.Schema, excluded map[string]bool) (spec.Schema, bool) {
+	if name, ok := definitionRefName(schema.Ref); ok && excluded[name] {
+		return schema, false
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		itemsSchema, keep := pruneExcludedRefs(*schema.Items.Schema, excluded)
+		if !keep {
+			return schema, false
+		}
+		items := *schema.Items
+		items.Schema = &itemsSchema
+		schema.Items = &items
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		additionalPropertiesSchema, keep := pruneExcludedRefs(*schema.AdditionalProperties.Schema, excluded)
+		if !keep {
+			return schema, false
+		}
+		additionalProperties := *schema.AdditionalProperties
+		additionalProperties.Schema = &additionalPropertiesSchema
+		schema.AdditionalProperties = &additionalProperties
+	}
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]spec.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if property, keep := pruneExcludedRefs(property, excluded); keep {
+				properties[name] = property
+			}
+		}
+		if len(properties) < len(schema.Properties) {
+			var required []string
+			for _, name := range schema.Required {
+				if _, ok := properties[name]; ok {
+					required = append(required, name)
+				}
+			}
+			schema.Required = required
+		}
+		schema.Properties = properties
+	}
+	for _, schemas := range []*[]spec.Schema{&schema.AllOf, &schema.AnyOf, &schema.OneOf} {
+		if len(*schemas) == 0 {
+			continue
+		}
+		var pruned []spec.Schema
+		for _, member := range *schemas {
+			if member, keep := pruneExcludedRefs(member, excluded); keep {
+				pruned = append(pruned, member)
+			}
+		}
+		*schemas = pruned
+	}
+	return schema, true
+}
+
+// definitionRefName returns the name of the definition referenced by ref, if it is a local reference to a definition.
+func definitionRefName(ref spec.Ref) (string, bool) {
+	if !ref.HasFragmentOnly {
+		return "", false
+	}
+	tokens := ref.GetPointer().DecodedTokens()
+	if len(tokens) != 2 || tokens[0] != "definitions" {
+		return "", false
+	}
+	return tokens[1], true
+}
+
+// splitPathPatterns removes the regular expressions constraining the path parameters of a go-restful
+// path template, e.g. "/items/{id:[0-9]+}", and returns the clean template, e.g. "/items/{id}", and the
+// regular expressions by parameter name. The "*" wildcard of trailing parameters is removed but is not
+// a pattern.
+func splitPathPatterns(path string) (string, map[string]string) {
+	if !strings.Contains(path, ":") {
+		return path, nil
+	}
+	var clean strings.Builder
+	patterns := map[string]string{}
+	for i := 0; i < len(path); {
+		if path[i] != '{' {
+			clean.WriteByte(path[i])
+			i++
+			continue
+		}
+		// regular expressions may contain braces, e.g. {id:[0-9]{3}}
+		end, depth := i, 0
+		for ; end < len(path); end++ {
+			if path[end] == '{' {
+				depth++
+			} else if path[end] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if end == len(path) {
+			clean.WriteString(path[i:])
+			break
+		}
+		param := path[i+1 : end]
+		if colon := strings.Index(param, ":"); colon >= 0 {
+			if pattern := param[colon+1:]; pattern != "" && pattern != "*" {
+				patterns[param[:colon]] = pattern
+			}
+			param = param[:colon]
+		}
+		clean.WriteString("{" + param + "}")
+		i = end + 1
+	}
+	return clean.String(), patterns
+}
+
+// setPathPatterns sets the pattern of the path parameters which have one in patterns.
+func setPathPatterns(params []spec.Parameter, patterns map[string]string) {
+	for i := range params {
+		if pattern, ok := patterns[params[i].Name]; ok && params[i].In == "path" {
+			params[i].Pattern = pattern
+		}
+	}
+}
+
+// checkListTypes returns an error listing the array properties of the definitions whose list type differs
+// from the one expected for their name, see common.Config.ExpectedListTypes.
+func checkListTypes(definitions spec.Definitions, expected map[string]string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mismatches []string
+	for _, name := range names {
+		mismatches = append(mismatches, listTypeMismatches(name, definitions[name], expected)...)
+	}
+	return listTypesError(mismatches)
+}
+
+// listTypeMismatches describes the array properties of the definition name whose list type differs from
+// the one expected for their name, in the order of their names.
+func listTypeMismatches(name string, def spec.Schema, expected map[string]string) []string {
+	if len(expected) == 0 {
+		return nil
+	}
+	props := make([]string, 0, len(def.Properties))
+	for prop := range def.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	var mismatches []string
+	for _, prop := range props {
+		want, ok := expected[prop]
+		schema := def.Properties[prop]
+		if !ok || !schema.Type.Contains("array") {
+			continue
+		}
+		got, ok := schema.Extensions.GetString(common.ExtensionListType)
+		if !ok {
+			got = "atomic"
+		}
+		if got != want {
+			mismatches = append(mismatches, fmt.Sprintf("%s.%s is %s, expected %s", name, prop, got, want))
+		}
+	}
+	return mismatches
+}
+
+func listTypesError(mismatches []string) error {
+	if len(mismatches) > 0 {
+		return fmt.Errorf("unexpected list types: %s", strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// watchEventSchema returns the schema of the events streamed by watch routes returning objects of schema
+// object. The stream is newline-delimited: each of its lines is an event, holding its type and the object
+// it is about.
+func watchEventSchema(object *spec.Schema) *spec.Schema {
+	return &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Description: "Event of a newline-delimited stream of watch events.",
+			Type:        []string{"object"},
+			Required:    []string{"type", "object"},
+			Properties: map[string]spec.Schema{
+				"type": {
+					SchemaProps: spec.SchemaProps{
+						Description: "Type of the event, e.g. ADDED, MODIFIED or DELETED.",
+						Type:        []string{"string"},
+					},
+				},
+				"object": *object,
+			},
+		},
+	}
+}
+
+// watchMediaTypes returns the media types followed by their ";stream=watch" variants, for the operations
+// streaming watch events.
+func watchMediaTypes(mediaTypes []string) []string {
+	if len(mediaTypes) == 0 {
+		return mediaTypes
+	}
+	ret := append(make([]string, 0, 2*len(mediaTypes)), mediaTypes...)
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, ";stream=") {
+			continue
+		}
+		if stream := mediaType + ";stream=watch"; !containsString(ret, stream) {
+			ret = append(ret, stream)
+		}
+	}
+	return ret
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// equalStrings returns true if a and b hold the same strings in the same order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func groupRoutesByPath(routes []restful.Route) map[string][]restful.Route {
 	pathToRoutes := make(map[string][]restful.Route)
 	for _, r := range routes {
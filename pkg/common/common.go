@@ -28,12 +28,53 @@ const (
 	// TODO: Make this configurable.
 	ExtensionPrefix   = "x-kubernetes-"
 	ExtensionV2Schema = ExtensionPrefix + "v2-schema"
+
+	// ExtensionSelectableFields lists the field paths of a definition which can be used in field selectors.
+	ExtensionSelectableFields = ExtensionPrefix + "selectable-fields"
+
+	// ExtensionListType is the topology of a list: "atomic" (the default), "set" or "map".
+	ExtensionListType = ExtensionPrefix + "list-type"
+
+	// ExtensionUnions describes the union fields of a definition, used by server-side apply.
+	ExtensionUnions = ExtensionPrefix + "unions"
+
+	// ExtensionAction is the kind of action of an operation, e.g. "watch".
+	ExtensionAction = ExtensionPrefix + "action"
+
+	// ExtensionGroupVersionKind lists the group, version and kind of the objects an operation acts on.
+	ExtensionGroupVersionKind = ExtensionPrefix + "group-version-kind"
+
+	// RouteSummaryMetadataKey is the go-restful route metadata key holding the summary of the operation.
+	RouteSummaryMetadataKey = "openapi.summary"
+
+	// RouteAutoGeneratedMetadataKey is the go-restful route metadata key flagging routes which were added
+	// automatically rather than documented explicitly, e.g. CORS preflight OPTIONS routes. Its value is a bool.
+	RouteAutoGeneratedMetadataKey = "openapi.auto-generated"
+
+	// RouteDeprecatedMetadataKey is the go-restful route metadata key flagging deprecated routes, whose
+	// operation is then marked deprecated. Its value is a bool. The go-restful version this package builds
+	// against has no RouteBuilder.Deprecate, hence the metadata.
+	RouteDeprecatedMetadataKey = "openapi.deprecated"
+
+	// RouteSchemesMetadataKey is the go-restful route metadata key holding the schemes of the operation
+	// when they differ from Config.ProtocolList, e.g. "wss" for websocket routes. Its value is a []string.
+	RouteSchemesMetadataKey = "openapi.schemes"
+
+	// RouteWatchMetadataKey is the go-restful route metadata key flagging watch routes, which stream the
+	// objects they return as newline-delimited events. Their operation has the "watch" ExtensionAction,
+	// also produces the ";stream=watch" variants of the media types of the route, and its successful
+	// responses are described by the schema of an event, holding its type and object. Its value is a bool.
+	RouteWatchMetadataKey = "openapi.watch"
 )
 
 // OpenAPIDefinition describes single type. Normally these definitions are auto-generated using gen-openapi.
 type OpenAPIDefinition struct {
 	Schema       spec.Schema
 	Dependencies []string
+	// RequiredByDefault lists the properties of Schema without optional or required marker which are not
+	// pointers: the builder lists them as required if and only if Config.RequiredByDefault is set, see
+	// IsFieldRequired. openapi-gen sets it when run with --required-by-default.
+	RequiredByDefault []string
 }
 
 type ReferenceCallback func(path string) spec.Ref
@@ -65,10 +106,27 @@ type Config struct {
 	// Info is general information about the API.
 	Info *spec.Info
 
+	// InfoVersionFunc returns the version of the API. It is an optional function, called on each build of
+	// the spec, which overrides Info.Version.
+	InfoVersionFunc func() string
+
+	// Host is the host (name or ip) serving the API, e.g. "api.example.com:6443". It is an optional field
+	// copied into the spec; when empty, consumers use the host serving the spec.
+	Host string
+
+	// BasePath is the path, relative to the host, on which the API is served. It is an optional field
+	// copied into the spec and must start with a "/".
+	BasePath string
+
 	// DefaultResponse will be used if an operation does not have any responses listed. It
 	// will show up as ... "responses" : {"default" : $DefaultResponse} in the spec.
 	DefaultResponse *spec.Response
 
+	// FillEmptyResponses adds a 200 response described as "default response" to the operations which still
+	// have no response once DefaultResponse is applied, rather than emitting an empty "responses" object,
+	// which strict validators reject.
+	FillEmptyResponses bool
+
 	// ResponseDefinitions will be added to "responses" under the top-level swagger object. This is an object
 	// that holds responses definitions that can be used across operations. This property does not define
 	// global responses for all operations. For more info please refer:
@@ -90,12 +148,30 @@ type Config struct {
 	GetOperationIDAndTags func(r *restful.Route) (string, []string, error)
 
 	// GetDefinitionName returns a friendly name for a definition base on the serving path. parameter `name` is the full name of the definition.
-	// It is an optional function to customize model names.
+	// It is an optional function to customize model names. The build fails if two types referenced by the spec get the same name.
 	GetDefinitionName func(name string) (string, spec.Extensions)
 
 	// PostProcessSpec runs after the spec is ready to serve. It allows a final modification to the spec before serving.
 	PostProcessSpec func(*spec.Swagger) (*spec.Swagger, error)
 
+	// PostProcessSchema runs on the schema of each definition, given its name in the spec, before it is added
+	// to the spec, e.g. to add extensions to a subset of the definitions. It is optional. The extensions of the
+	// schema may be modified, while its other maps and slices are shared with the definitions returned by
+	// GetDefinitions and must be replaced rather than modified. An error fails the build. It must be safe
+	// for concurrent use if BuildDefinitionsConcurrently is set.
+	PostProcessSchema func(name string, schema *spec.Schema) error
+
+	// BuildDefinitionsConcurrently processes the schemas of the definitions referenced by the spec (default
+	// property descriptions, selectable fields, unions, PostProcessSchema, pruning of the references to
+	// definitions left out by IncludeDefinition, ExpectedListTypes checks, ...) on a pool of
+	// DefinitionsConcurrency goroutines once all the routes are built, rather than one at a time as they
+	// are referenced. GetDefinitions is still called once, before. The resulting spec is the same either way.
+	BuildDefinitionsConcurrently bool
+
+	// DefinitionsConcurrency is the number of goroutines processing definitions if BuildDefinitionsConcurrently
+	// is set. It defaults to GOMAXPROCS.
+	DefinitionsConcurrency int
+
 	// SecurityDefinitions is list of all security definitions for OpenAPI service. If this is not nil, the user of config
 	// is responsible to provide DefaultSecurity and (maybe) add unauthorized response to CommonResponses.
 	SecurityDefinitions *spec.SecurityDefinitions
@@ -103,6 +179,85 @@ type Config struct {
 	// DefaultSecurity for all operations. This will pass as spec.SwaggerProps.Security to OpenAPI.
 	// For most cases, this will be list of acceptable definitions in SecurityDefinitions.
 	DefaultSecurity []map[string][]string
+
+	// DefaultPropertyDescriptions maps property names (e.g. "name", "namespace") to descriptions. It is an
+	// optional dictionary used to fill in the description of definition properties that do not have one.
+	// Existing descriptions are never overridden.
+	DefaultPropertyDescriptions map[string]string
+
+	// RequiredByDefault controls whether struct fields without an explicit +optional or +required marker
+	// are required. Pointer fields are optional unless marked +required. See IsFieldRequired; it applies
+	// to the properties listed in OpenAPIDefinition.RequiredByDefault, whatever the value of
+	// --required-by-default that openapi-gen was run with.
+	RequiredByDefault bool
+
+	// IncludeRouteNotes appends the notes of routes, i.e. their extended documentation, to the description
+	// of their operation, separated from the route documentation by a blank line.
+	IncludeRouteNotes bool
+
+	// SummaryFromDescription fills in the summary of operations whose route has no RouteSummaryMetadataKey
+	// metadata with the first line of their description, truncated if too long.
+	SummaryFromDescription bool
+
+	// OmitNoContentResponseSchemas drops the schema of 204 (No Content) and 304 (Not Modified) responses,
+	// which have no body, even if their route declares a model. Responses declared without model never
+	// have a schema.
+	OmitNoContentResponseSchemas bool
+
+	// InlineArrayItemRefs replaces the $ref to a definition in the items of array properties by the schema
+	// of that definition, for consumers which cannot follow such references. Definitions are not inlined
+	// into themselves: the items of recursive types keep their $ref.
+	InlineArrayItemRefs bool
+
+	// HoistInlineSchemasMinSize, if positive, moves the inline object schemas (i.e. with properties) which occur
+	// more than once in the definitions, and whose JSON encoding is at least that many bytes long, to new
+	// definitions named "inline.<hash of the schema>". Their occurrences are replaced by a $ref, which
+	// shrinks specs repeating large anonymous structs.
+	HoistInlineSchemasMinSize int
+
+	// SkipOptions omits the OPTIONS operations of routes flagged with the RouteAutoGeneratedMetadataKey
+	// metadata, e.g. CORS preflight handlers. Explicitly documented OPTIONS operations are kept.
+	SkipOptions bool
+
+	// GetSelectableFields returns the paths of the fields of a definition, given its name in the spec, which
+	// can be used in field selectors (e.g. "spec.nodeName"). They are advertised in the ExtensionSelectableFields
+	// extension of the definition. It is optional; returning nil omits the extension.
+	GetSelectableFields func(defName string) []string
+
+	// GetUnions returns the union descriptors of a definition, given its name in the spec. They are
+	// advertised in the ExtensionUnions extension of the definition. It is optional; returning nil
+	// omits the extension.
+	GetUnions func(defName string) []interface{}
+
+	// IncludeDefinition reports whether a definition, given its name in the spec, is part of the spec, e.g.
+	// to leave out the types of disabled feature-gated APIs. It is optional; all definitions are included
+	// by default. The properties of included definitions referencing an excluded definition are pruned,
+	// while a route referencing an excluded definition fails the build.
+	IncludeDefinition func(defName string) bool
+
+	// GetOperationGVK returns the group, version and kind (as "group", "version" and "kind" keys) of the
+	// objects the operation of a route acts on, given its HTTP method and path. They are advertised in the
+	// ExtensionGroupVersionKind extension of the operation. It is optional; returning nil omits the extension.
+	GetOperationGVK func(method, path string) []map[string]string
+
+	// ExpectedListTypes maps property names (e.g. "finalizers") to the ExtensionListType that the array
+	// properties of that name must have in every definition, lists without the extension being atomic.
+	// The build fails on mismatch. It is optional; list types are not checked by default.
+	ExpectedListTypes map[string]string
+}
+
+// IsFieldRequired returns whether a struct field is required. An explicit +required marker wins over an
+// optional marker (+optional or omitempty). Fields without marker are required if requiredByDefault is set,
+// except for pointers which default to optional. openapi-gen applies it when run with --required-by-default,
+// the builder with Config.RequiredByDefault.
+func IsFieldRequired(hasOptionalMarker, hasRequiredMarker, isPointer, requiredByDefault bool) bool {
+	if hasRequiredMarker {
+		return true
+	}
+	if hasOptionalMarker || isPointer {
+		return false
+	}
+	return requiredByDefault
 }
 
 type typeInfo struct {
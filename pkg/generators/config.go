@@ -64,8 +64,16 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 `)...)
 
 	reportPath := "-"
+	var options typeWriterOptions
 	if customArgs, ok := arguments.CustomArgs.(*generatorargs.CustomArgs); ok {
 		reportPath = customArgs.ReportFilename
+		options.requiredByDefault = customArgs.RequiredByDefault
+		options.nullablePointers = customArgs.NullablePointers
+		options.definitionNamer = customArgs.DefinitionNamer
+	}
+	options.markers = newMarkerPositions()
+	for _, input := range context.Inputs {
+		options.markers.addPackage(context.Universe.Package(input))
 	}
 	context.FileTypes[apiViolationFileType] = apiViolationFile{
 		unmangledPath: reportPath,
@@ -81,6 +89,7 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 					newOpenAPIGen(
 						arguments.OutputFileBaseName,
 						arguments.OutputPackagePath,
+						options,
 					),
 					newAPIViolationGen(),
 				}
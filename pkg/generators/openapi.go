@@ -23,6 +23,7 @@ import (
 	"io"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -37,12 +38,16 @@ import (
 // This is the comment tag that carries parameters for open API generation.
 const tagName = "k8s:openapi-gen"
 const tagOptional = "optional"
+const tagRequired = "required"
 const tagDefault = "default"
+const tagPattern = "k8s:validation:pattern"
 
 // Known values for the tag.
 const (
 	tagValueTrue  = "true"
 	tagValueFalse = "false"
+	// tagValueStrict closes a struct type to unknown fields, see generate.
+	tagValueStrict = "strict"
 )
 
 // Used for temporary validation of patch struct tags.
@@ -87,6 +92,31 @@ func hasOptionalTag(m *types.Member) bool {
 	return hasOptionalCommentTag || hasOptionalJsonTag
 }
 
+// hasRequiredTag returns true if the member has +required in its comments.
+func hasRequiredTag(m *types.Member) bool {
+	return types.ExtractCommentTags("+", m.CommentLines)[tagRequired] != nil
+}
+
+// isMemberRequired returns true if the member should be listed as required. Without requiredByDefault,
+// every member without optional tag is required; otherwise the rule of common.IsFieldRequired applies.
+// Slices and maps with omitempty in their json tags are never required, whatever their comment tags,
+// since they are left out of the serialization when empty.
+func isMemberRequired(m *types.Member, requiredByDefault *bool) bool {
+	if isSliceOrMap(m.Type) && strings.Contains(reflect.StructTag(m.Tags).Get("json"), "omitempty") {
+		return false
+	}
+	if requiredByDefault == nil {
+		return !hasOptionalTag(m)
+	}
+	return openapi.IsFieldRequired(hasOptionalTag(m), hasRequiredTag(m), isPointer(m.Type), *requiredByDefault)
+}
+
+// isMemberRequiredByDefault returns true if the member has neither optional nor required tag and is not
+// a pointer, i.e. if it is required exactly when requiredByDefault is set.
+func isMemberRequiredByDefault(m *types.Member) bool {
+	return !hasOptionalTag(m) && !hasRequiredTag(m) && !isPointer(m.Type)
+}
+
 func apiTypeFilterFunc(c *generator.Context, t *types.Type) bool {
 	// There is a conflict between this codegen and codecgen, we should avoid types generated for codecgen
 	if strings.HasPrefix(t.Name.Name, "codecSelfer") {
@@ -113,15 +143,37 @@ type openAPIGen struct {
 	// TargetPackage is the package that will get GetOpenAPIDefinitions function returns all open API definitions.
 	targetPackage string
 	imports       namer.ImportTracker
+	options       typeWriterOptions
+}
+
+// typeWriterOptions holds the settings of the generation of the definitions.
+type typeWriterOptions struct {
+	// requiredByDefault is the policy for members without optional or required tags, see isMemberRequired.
+	requiredByDefault *bool
+	// nullablePointers flags pointer to primitive members as nullable, with the x-nullable extension.
+	nullablePointers bool
+	// definitionNamer maps canonical type names to the names of their definitions, if set.
+	definitionNamer func(name string) string
+	// markers locates marker errors in the sources of the input packages, if set.
+	markers *markerPositions
+}
+
+// definitionName returns the name of the definition of t, used both as its key and in references to it.
+func (o typeWriterOptions) definitionName(t *types.Type) string {
+	if o.definitionNamer == nil {
+		return t.Name.String()
+	}
+	return o.definitionNamer(t.Name.String())
 }
 
-func newOpenAPIGen(sanitizedName string, targetPackage string) generator.Generator {
+func newOpenAPIGen(sanitizedName string, targetPackage string, options typeWriterOptions) generator.Generator {
 	return &openAPIGen{
 		DefaultGen: generator.DefaultGen{
 			OptionalName: sanitizedName,
 		},
 		imports:       generator.NewImportTracker(),
 		targetPackage: targetPackage,
+		options:       options,
 	}
 }
 
@@ -173,7 +225,7 @@ func (g *openAPIGen) Init(c *generator.Context, w io.Writer) error {
 	sw.Do("return map[string]$.OpenAPIDefinition|raw${\n", argsFromType(nil))
 
 	for _, t := range c.Order {
-		err := newOpenAPITypeWriter(sw, c).generateCall(t)
+		err := newOpenAPITypeWriter(sw, c, g.options).generateCall(t)
 		if err != nil {
 			return err
 		}
@@ -188,7 +240,7 @@ func (g *openAPIGen) Init(c *generator.Context, w io.Writer) error {
 func (g *openAPIGen) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
 	klog.V(5).Infof("generating for type %v", t)
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
-	err := newOpenAPITypeWriter(sw, c).generate(t)
+	err := newOpenAPITypeWriter(sw, c, g.options).generate(t)
 	if err != nil {
 		return err
 	}
@@ -226,13 +278,15 @@ type openAPITypeWriter struct {
 	context                *generator.Context
 	refTypes               map[string]*types.Type
 	GetDefinitionInterface *types.Type
+	options                typeWriterOptions
 }
 
-func newOpenAPITypeWriter(sw *generator.SnippetWriter, c *generator.Context) openAPITypeWriter {
+func newOpenAPITypeWriter(sw *generator.SnippetWriter, c *generator.Context, options typeWriterOptions) openAPITypeWriter {
 	return openAPITypeWriter{
 		SnippetWriter: sw,
 		context:       c,
 		refTypes:      map[string]*types.Type{},
+		options:       options,
 	}
 }
 
@@ -282,35 +336,60 @@ func typeShortName(t *types.Type) string {
 	return filepath.Base(t.Name.Package) + "." + t.Name.Name
 }
 
-func (g openAPITypeWriter) generateMembers(t *types.Type, required []string) ([]string, error) {
-	var err error
+// requiredMembers collects the json names of the required members of a type.
+type requiredMembers struct {
+	required []string
+	// byDefault are the members whose requiredness only depends on the requiredByDefault policy,
+	// listed only when the policy is set, see common.OpenAPIDefinition.RequiredByDefault.
+	byDefault []string
+}
+
+func (g openAPITypeWriter) generateMembers(t *types.Type, required *requiredMembers) error {
+	return g.generateMembersShadowedBy(t, required, nil)
+}
+
+// generateMembersShadowedBy generates the members of t except the ones named in shadowed, i.e. the
+// members of an inlined struct which are hidden by a member of the same name of an outer struct, like
+// encoding/json does. A member excluded with +k8s:openapi-gen=false also hides inlined members.
+func (g openAPITypeWriter) generateMembersShadowedBy(t *types.Type, required *requiredMembers, shadowed map[string]bool) error {
 	for t.Kind == types.Pointer { // fast-forward to effective type containing members
 		t = t.Elem
 	}
+	inlineShadowed := map[string]bool{}
+	for k := range shadowed {
+		inlineShadowed[k] = true
+	}
+	for _, m := range t.Members {
+		if !shouldInlineMembers(&m) {
+			inlineShadowed[getReferableName(&m)] = true
+		}
+	}
 	for _, m := range t.Members {
 		if hasOpenAPITagValue(m.CommentLines, tagValueFalse) {
 			continue
 		}
 		if shouldInlineMembers(&m) {
-			required, err = g.generateMembers(m.Type, required)
-			if err != nil {
-				return required, err
+			if err := g.generateMembersShadowedBy(m.Type, required, inlineShadowed); err != nil {
+				return err
 			}
 			continue
 		}
 		name := getReferableName(&m)
-		if name == "" {
+		if name == "" || shadowed[name] {
 			continue
 		}
-		if !hasOptionalTag(&m) {
-			required = append(required, name)
+		if isMemberRequired(&m, g.options.requiredByDefault) {
+			required.required = append(required.required, name)
+		}
+		if g.options.requiredByDefault != nil && isMemberRequiredByDefault(&m) {
+			required.byDefault = append(required.byDefault, name)
 		}
-		if err = g.generateProperty(&m, t); err != nil {
+		if err := g.generateProperty(&m, t); err != nil {
 			klog.Errorf("Error when generating: %v, %v\n", name, m)
-			return required, err
+			return err
 		}
 	}
-	return required, nil
+	return nil
 }
 
 func (g openAPITypeWriter) generateCall(t *types.Type) error {
@@ -318,7 +397,7 @@ func (g openAPITypeWriter) generateCall(t *types.Type) error {
 	switch t.Kind {
 	case types.Struct:
 		args := argsFromType(t)
-		g.Do("\"$.$\": ", t.Name)
+		g.Do("\"$.$\": ", g.options.definitionName(t))
 
 		hasV2Definition := hasOpenAPIDefinitionMethod(t)
 		hasV2DefinitionTypeAndFormat := hasOpenAPIDefinitionMethods(t)
@@ -388,8 +467,8 @@ func (g openAPITypeWriter) generate(t *types.Type) error {
 		propertiesBuf := bytes.Buffer{}
 		bsw := g
 		bsw.SnippetWriter = generator.NewSnippetWriter(&propertiesBuf, g.context, "$", "$")
-		required, err := bsw.generateMembers(t, []string{})
-		if err != nil {
+		required := &requiredMembers{}
+		if err := bsw.generateMembers(t, required); err != nil {
 			return err
 		}
 		if propertiesBuf.Len() > 0 {
@@ -398,8 +477,12 @@ func (g openAPITypeWriter) generate(t *types.Type) error {
 			g.Do("},\n", nil)
 		}
 
-		if len(required) > 0 {
-			g.Do("Required: []string{\"$.$\"},\n", strings.Join(required, "\",\""))
+		if len(required.required) > 0 {
+			g.Do("Required: []string{\"$.$\"},\n", strings.Join(required.required, "\",\""))
+		}
+		if hasOpenAPITagValue(t.CommentLines, tagValueStrict) {
+			// the explicit false form, marshaled as "additionalProperties": false
+			g.Do("AdditionalProperties: &spec.SchemaOrBool{\nAllows: false,\n},\n", nil)
 		}
 		g.Do("},\n", nil)
 		if err := g.generateStructExtensions(t); err != nil {
@@ -421,8 +504,9 @@ func (g openAPITypeWriter) generate(t *types.Type) error {
 				// Will eliminate special case of time.Time
 				continue
 			}
-			deps = append(deps, k)
+			deps = append(deps, g.options.definitionName(v))
 		}
+		sort.Strings(deps)
 		if len(deps) > 0 {
 			g.Do("Dependencies: []string{\n", args)
 			for _, k := range deps {
@@ -430,6 +514,9 @@ func (g openAPITypeWriter) generate(t *types.Type) error {
 			}
 			g.Do("},\n", nil)
 		}
+		if len(required.byDefault) > 0 {
+			g.Do("RequiredByDefault: []string{\"$.$\"},\n", strings.Join(required.byDefault, "\",\""))
+		}
 		g.Do("}\n}\n\n", nil)
 	}
 	return nil
@@ -439,40 +526,56 @@ func (g openAPITypeWriter) generateStructExtensions(t *types.Type) error {
 	extensions, errors := parseExtensions(t.CommentLines)
 	// Initially, we will only log struct extension errors.
 	if len(errors) > 0 {
-		for _, e := range errors {
-			klog.Errorf("[%s]: %s\n", t.String(), e)
+		for _, e := range markerErrors(t, "", tagName, errors) {
+			klog.Errorf("[%s]: %s\n", t.String(), g.locate(e))
 		}
 	}
 	unions, errors := parseUnions(t)
 	if len(errors) > 0 {
 		for _, e := range errors {
-			klog.Errorf("[%s]: %s\n", t.String(), e)
+			klog.Errorf("[%s]: %s\n", t.String(), g.locate(e))
 		}
 	}
 
 	// TODO(seans3): Validate struct extensions here.
-	g.emitExtensions(extensions, unions)
+	g.emitExtensions(extensions, unions, false)
 	return nil
 }
 
 func (g openAPITypeWriter) generateMemberExtensions(m *types.Member, parent *types.Type) error {
 	extensions, parseErrors := parseExtensions(m.CommentLines)
-	validationErrors := validateMemberExtensions(extensions, m)
-	errors := append(parseErrors, validationErrors...)
+	errors := markerErrors(parent, m.Name, tagName, parseErrors)
+	for _, e := range extensions {
+		errors = append(errors, markerErrors(parent, m.Name, e.idlTag, validateMemberExtensions([]extension{e}, m))...)
+	}
 	// Initially, we will only log member extension errors.
 	if len(errors) > 0 {
 		errorPrefix := fmt.Sprintf("[%s] %s:", parent.String(), m.String())
 		for _, e := range errors {
-			klog.V(2).Infof("%s %s\n", errorPrefix, e)
+			klog.V(2).Infof("%s %s\n", errorPrefix, g.locate(e))
 		}
 	}
-	g.emitExtensions(extensions, nil)
+	g.emitExtensions(extensions, nil, g.isNullable(m))
 	return nil
 }
 
-func (g openAPITypeWriter) emitExtensions(extensions []extension, unions []union) {
+// isNullable returns true if the member is a pointer to a primitive type and nullablePointers is set.
+func (g openAPITypeWriter) isNullable(m *types.Member) bool {
+	if !g.options.nullablePointers || !isPointer(m.Type) {
+		return false
+	}
+	if jsonTags := getJsonTags(m); len(jsonTags) > 1 && jsonTags[1] == "string" {
+		return false
+	}
+	typeString, _ := openapi.OpenAPITypeFormat(resolveAliasAndPtrType(m.Type).String())
+	return typeString != ""
+}
+
+// emitExtensions emits the vendor extensions of a schema, including x-nullable if nullable is set: the
+// OpenAPI v2 spec has no nullable keyword.
+func (g openAPITypeWriter) emitExtensions(extensions []extension, unions []union, nullable bool) {
 	// If any extensions exist, then emit code to create them.
-	if len(extensions) == 0 && len(unions) == 0 {
+	if len(extensions) == 0 && len(unions) == 0 && !nullable {
 		return
 	}
 	g.Do("VendorExtensible: spec.VendorExtensible{\nExtensions: spec.Extensions{\n", nil)
@@ -495,6 +598,9 @@ func (g openAPITypeWriter) emitExtensions(extensions []extension, unions []union
 		}
 		g.Do("},\n", nil)
 	}
+	if nullable {
+		g.Do("\"x-nullable\": true,\n", nil)
+	}
 	g.Do("},\n},\n", nil)
 }
 
@@ -505,11 +611,11 @@ func (g openAPITypeWriter) validatePatchTags(m *types.Member, parent *types.Type
 		structTagValue := reflect.StructTag(m.Tags).Get(tagKey)
 		commentTagValue, err := getSingleTagsValue(m.CommentLines, tagKey)
 		if err != nil {
-			return err
+			return g.markerError(parent, m, tagKey, err)
 		}
 		if structTagValue != commentTagValue {
-			return fmt.Errorf("Tags in comment and struct should match for member (%s) of (%s)",
-				m.Name, parent.Name.String())
+			return g.markerError(parent, m, tagKey, fmt.Errorf("Tags in comment and struct should match for member (%s) of (%s)",
+				m.Name, parent.Name.String()))
 		}
 	}
 	return nil
@@ -527,6 +633,18 @@ func defaultFromComments(comments []string) (interface{}, error) {
 	return i, nil
 }
 
+// patternFromComments returns the regular expression of the pattern tag, checking that it compiles.
+func patternFromComments(comments []string) (string, error) {
+	pattern, err := getSingleTagsValue(comments, tagPattern)
+	if pattern == "" {
+		return "", err
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	return pattern, nil
+}
+
 func mustEnforceDefault(t *types.Type, omitEmpty bool) (interface{}, error) {
 	switch t.Kind {
 	case types.Pointer, types.Map, types.Slice, types.Array, types.Interface:
@@ -626,21 +744,30 @@ func (g openAPITypeWriter) generateProperty(m *types.Member, parent *types.Type)
 	}
 	g.Do("SchemaProps: spec.SchemaProps{\n", nil)
 	g.generateDescription(m.CommentLines)
+	pattern, err := patternFromComments(m.CommentLines)
+	if err != nil {
+		return g.markerError(parent, m, tagPattern, fmt.Errorf("failed to generate pattern in %v: %v: %v", parent, m.Name, err))
+	}
 	jsonTags := getJsonTags(m)
 	if len(jsonTags) > 1 && jsonTags[1] == "string" {
 		g.generateSimpleProperty("string", "")
+		g.generatePattern(pattern)
 		g.Do("},\n},\n", nil)
 		return nil
 	}
 	omitEmpty := strings.Contains(reflect.StructTag(m.Tags).Get("json"), "omitempty")
 	if err := g.generateDefault(m.CommentLines, m.Type, omitEmpty); err != nil {
-		return fmt.Errorf("failed to generate default in %v: %v: %v", parent, m.Name, err)
+		return g.markerError(parent, m, tagDefault, fmt.Errorf("failed to generate default in %v: %v: %v", parent, m.Name, err))
 	}
 	t := resolveAliasAndPtrType(m.Type)
 	// If we can get a openAPI type and format for this type, we consider it to be simple property
 	typeString, format := openapi.OpenAPITypeFormat(t.String())
+	if pattern != "" && typeString != "string" {
+		return g.markerError(parent, m, tagPattern, fmt.Errorf("failed to generate pattern in %v: %v: patterns only apply to strings", parent, m.Name))
+	}
 	if typeString != "" {
 		g.generateSimpleProperty(typeString, format)
+		g.generatePattern(pattern)
 		g.Do("},\n},\n", nil)
 		return nil
 	}
@@ -669,9 +796,15 @@ func (g openAPITypeWriter) generateSimpleProperty(typeString, format string) {
 	g.Do("Format: \"$.$\",\n", format)
 }
 
+func (g openAPITypeWriter) generatePattern(pattern string) {
+	if pattern != "" {
+		g.Do("Pattern: $.$,\n", fmt.Sprintf("%q", pattern))
+	}
+}
+
 func (g openAPITypeWriter) generateReferenceProperty(t *types.Type) {
 	g.refTypes[t.Name.String()] = t
-	g.Do("Ref: ref(\"$.$\"),\n", t.Name.String())
+	g.Do("Ref: ref(\"$.$\"),\n", g.options.definitionName(t))
 }
 
 func resolveAliasAndEmbeddedType(t *types.Type) *types.Type {
@@ -690,6 +823,22 @@ func resolveAliasAndEmbeddedType(t *types.Type) *types.Type {
 	return t
 }
 
+// isPointer returns true if t is a pointer, possibly behind aliases.
+func isPointer(t *types.Type) bool {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t.Kind == types.Pointer
+}
+
+// isSliceOrMap returns true if t is a slice or a map, possibly behind aliases.
+func isSliceOrMap(t *types.Type) bool {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t.Kind == types.Slice || t.Kind == types.Map
+}
+
 func resolveAliasAndPtrType(t *types.Type) *types.Type {
 	var prev *types.Type
 	for prev != t {
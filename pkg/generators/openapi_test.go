@@ -19,7 +19,10 @@ package generators
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -48,6 +51,10 @@ func construct(t *testing.T, files map[string]string, testNamer namer.Namer) (*p
 }
 
 func testOpenAPITypeWriter(t *testing.T, code string) (error, error, *assert.Assertions, *bytes.Buffer, *bytes.Buffer) {
+	return testOpenAPITypeWriterWithOptions(t, code, typeWriterOptions{})
+}
+
+func testOpenAPITypeWriterWithOptions(t *testing.T, code string, options typeWriterOptions) (error, error, *assert.Assertions, *bytes.Buffer, *bytes.Buffer) {
 	assert := assert.New(t)
 	var testFiles = map[string]string{
 		"base/foo/bar.go": code,
@@ -71,11 +78,11 @@ func testOpenAPITypeWriter(t *testing.T, code string) (error, error, *assert.Ass
 
 	callBuffer := &bytes.Buffer{}
 	callSW := generator.NewSnippetWriter(callBuffer, context, "$", "$")
-	callError := newOpenAPITypeWriter(callSW, context).generateCall(blahT)
+	callError := newOpenAPITypeWriter(callSW, context, options).generateCall(blahT)
 
 	funcBuffer := &bytes.Buffer{}
 	funcSW := generator.NewSnippetWriter(funcBuffer, context, "$", "$")
-	funcError := newOpenAPITypeWriter(funcSW, context).generate(blahT)
+	funcError := newOpenAPITypeWriter(funcSW, context, options).generate(blahT)
 
 	return callError, funcError, assert, callBuffer, funcBuffer
 }
@@ -460,6 +467,82 @@ Dependencies: []string{
 `, funcBuffer.String())
 }
 
+func TestDefinitionNamer(t *testing.T) {
+	// reverseDNS turns "base/foo.Blah" into "foo.base.Blah"
+	reverseDNS := func(name string) string {
+		i := strings.LastIndex(name, ".")
+		segments := strings.Split(name[:i], "/")
+		for l, r := 0, len(segments)-1; l < r; l, r = l+1, r-1 {
+			segments[l], segments[r] = segments[r], segments[l]
+		}
+		return strings.Join(segments, ".") + name[i:]
+	}
+	callErr, funcErr, assert, callBuffer, funcBuffer := testOpenAPITypeWriterWithOptions(t, `
+package foo
+
+// Nested is used as struct field
+type Nested struct {
+  // A simple string
+  String string
+}
+
+// Blah demonstrate a struct with struct fields.
+type Blah struct {
+  // A struct field
+  Field Nested
+  // A map of structs
+  Map map[string]Nested
+}
+	`, typeWriterOptions{definitionNamer: reverseDNS})
+	if callErr != nil {
+		t.Fatal(callErr)
+	}
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	assert.Equal(`"foo.base.Blah": schema_base_foo_Blah(ref),
+`, callBuffer.String())
+	assert.Equal(`func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah demonstrate a struct with struct fields.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"Field": {
+SchemaProps: spec.SchemaProps{
+Description: "A struct field",
+Default: map[string]interface {}{},
+Ref: ref("foo.base.Nested"),
+},
+},
+"Map": {
+SchemaProps: spec.SchemaProps{
+Description: "A map of structs",
+Type: []string{"object"},
+AdditionalProperties: &spec.SchemaOrBool{
+Allows: true,
+Schema: &spec.Schema{
+SchemaProps: spec.SchemaProps{
+Default: map[string]interface {}{},
+Ref: ref("foo.base.Nested"),
+},
+},
+},
+},
+},
+},
+Required: []string{"Field","Map"},
+},
+},
+Dependencies: []string{
+"foo.base.Nested",},
+}
+}
+
+`, funcBuffer.String())
+}
+
 func TestNestedStructPointer(t *testing.T) {
 	callErr, funcErr, assert, callBuffer, funcBuffer := testOpenAPITypeWriter(t, `
 package foo
@@ -660,6 +743,102 @@ Required: []string{"String"},
 `, funcBuffer.String())
 }
 
+func TestExcludedField(t *testing.T) {
+	callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriter(t, `
+package foo
+
+// Blah demonstrate a struct with a field excluded from the schema.
+type Blah struct {
+  // A simple string
+  String string
+  // An excluded string
+  // +k8s:openapi-gen=false
+  Excluded string
+}
+	`)
+	if callErr != nil {
+		t.Fatal(callErr)
+	}
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	assert.Equal(`func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah demonstrate a struct with a field excluded from the schema.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"String": {
+SchemaProps: spec.SchemaProps{
+Description: "A simple string",
+Default: "",
+Type: []string{"string"},
+Format: "",
+},
+},
+},
+Required: []string{"String"},
+},
+},
+}
+}
+
+`, funcBuffer.String())
+}
+
+func TestExcludedInlinedField(t *testing.T) {
+	callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriter(t, `
+package foo
+
+// Nested is used as embedded inline struct field
+type Nested struct {
+  // A simple string
+  String string
+  // A nested string, hidden by Blah.Hidden
+  Hidden string `+"`"+`json:"hidden"`+"`"+`
+}
+
+// Blah demonstrate a struct excluding a field of an embedded inline struct.
+type Blah struct {
+  // An embedded inline struct field
+  Nested `+"`"+`json:",inline,omitempty"`+"`"+`
+  // An excluded string
+  // +k8s:openapi-gen=false
+  Hidden string `+"`"+`json:"hidden"`+"`"+`
+}
+	`)
+	if callErr != nil {
+		t.Fatal(callErr)
+	}
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	assert.Equal(`func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah demonstrate a struct excluding a field of an embedded inline struct.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"String": {
+SchemaProps: spec.SchemaProps{
+Description: "A simple string",
+Default: "",
+Type: []string{"string"},
+Format: "",
+},
+},
+},
+Required: []string{"String"},
+},
+},
+}
+}
+
+`, funcBuffer.String())
+}
+
 func TestEmbeddedInlineStructPointer(t *testing.T) {
 	callErr, funcErr, assert, callBuffer, funcBuffer := testOpenAPITypeWriter(t, `
 package foo
@@ -980,6 +1159,215 @@ type Item string	`,
 	}
 }
 
+func TestStrict(t *testing.T) {
+	callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriter(t, `
+package foo
+
+// Blah is a closed configuration.
+// +k8s:openapi-gen=true
+// +k8s:openapi-gen=strict
+type Blah struct {
+	// A name
+	Name string
+}
+	`)
+	if callErr != nil {
+		t.Fatal(callErr)
+	}
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	assert.Equal(`func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah is a closed configuration.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"Name": {
+SchemaProps: spec.SchemaProps{
+Description: "A name",
+Default: "",
+Type: []string{"string"},
+Format: "",
+},
+},
+},
+Required: []string{"Name"},
+AdditionalProperties: &spec.SchemaOrBool{
+Allows: false,
+},
+},
+},
+}
+}
+
+`, funcBuffer.String())
+}
+
+func TestPattern(t *testing.T) {
+	callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriter(t, `
+package foo
+
+// Blah is a test.
+type Blah struct {
+	// A lowercase name
+	// +k8s:validation:pattern=^[a-z0-9]+$
+	Name string
+	// A lowercase name, as a string pointer
+	// +k8s:validation:pattern=^[a-z0-9]+$
+	NamePointer *string `+"`json:\"namePointer,omitempty\"`"+`
+}
+`)
+	if callErr != nil {
+		t.Fatal(callErr)
+	}
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	assert.Equal(`func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah is a test.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"Name": {
+SchemaProps: spec.SchemaProps{
+Description: "A lowercase name",
+Default: "",
+Type: []string{"string"},
+Format: "",
+Pattern: "^[a-z0-9]+$",
+},
+},
+"namePointer": {
+SchemaProps: spec.SchemaProps{
+Description: "A lowercase name, as a string pointer",
+Type: []string{"string"},
+Format: "",
+Pattern: "^[a-z0-9]+$",
+},
+},
+},
+Required: []string{"Name"},
+},
+},
+}
+}
+
+`, funcBuffer.String())
+}
+
+func TestFailingPattern(t *testing.T) {
+	tests := []struct {
+		definition    string
+		expectedError error
+	}{
+		{
+			definition: `
+package foo
+
+type Blah struct {
+	// +k8s:validation:pattern=^[a-z+$
+	Name string
+}	`,
+			expectedError: fmt.Errorf("failed to generate pattern in base/foo.Blah: Name: invalid pattern %q: %v", "^[a-z+$", regexpError("^[a-z+$")),
+		},
+		{
+			definition: `
+package foo
+
+type Blah struct {
+	// +k8s:validation:pattern=^[0-9]+$
+	Int int
+}	`,
+			expectedError: fmt.Errorf("failed to generate pattern in base/foo.Blah: Int: patterns only apply to strings"),
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			_, funcErr, assert, _, _ := testOpenAPITypeWriter(t, test.definition)
+			if assert.Error(funcErr, "An error was expected") {
+				assert.Equal(test.expectedError, funcErr)
+			}
+		})
+	}
+}
+
+func TestFailingMarkerPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr func(file string) string
+	}{
+		{
+			name: "pattern",
+			code: `package foo
+
+type Blah struct {
+	// Name is a name.
+	// +k8s:validation:pattern=^[a-z+$
+	Name string
+}
+`,
+			wantErr: func(file string) string {
+				return fmt.Sprintf("%s:5:2: failed to generate pattern in base/foo.Blah: Name: invalid pattern %q: %v", file, "^[a-z+$", regexpError("^[a-z+$"))
+			},
+		},
+		{
+			name: "patch strategy",
+			code: `package foo
+
+type Blah struct {
+	// Names are names.
+	// +patchStrategy=merge
+	// +patchStrategy=replace
+	Names []string ` + "`patchStrategy:\"merge\"`" + `
+}
+`,
+			wantErr: func(file string) string {
+				return fmt.Sprintf("%s:5:2: multiple values are not allowed for tag patchStrategy", file)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "openapi-gen")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "bar.go")
+			if err := ioutil.WriteFile(file, []byte(test.code), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			builder, universe, _ := construct(t, map[string]string{"base/foo/bar.go": test.code}, namer.NewRawNamer("o", nil))
+			context, err := generator.NewContext(builder, namer.NameSystems{"raw": namer.NewRawNamer("", nil)}, "raw")
+			if err != nil {
+				t.Fatal(err)
+			}
+			context.Universe.Package("base/foo").SourcePath = dir
+			options := typeWriterOptions{markers: newMarkerPositions()}
+			options.markers.addPackage(context.Universe.Package("base/foo"))
+			blahT := universe.Type(types.Name{Package: "base/foo", Name: "Blah"})
+
+			sw := generator.NewSnippetWriter(&bytes.Buffer{}, context, "$", "$")
+			err = newOpenAPITypeWriter(sw, context, options).generate(blahT)
+			if assert.Error(t, err) {
+				assert.Equal(t, test.wantErr(file), err.Error())
+			}
+		})
+	}
+}
+
+func regexpError(pattern string) error {
+	_, err := regexp.Compile(pattern)
+	return err
+}
+
 func TestCustomDef(t *testing.T) {
 	callErr, funcErr, assert, callBuffer, funcBuffer := testOpenAPITypeWriter(t, `
 package foo
@@ -1252,6 +1640,167 @@ Dependencies: []string{
 `, funcBuffer.String())
 }
 
+func TestRequiredByDefault(t *testing.T) {
+	code := `
+package foo
+
+// Blah mixes pointer and value fields.
+type Blah struct {
+	Value string
+	Pointer *string
+	// +optional
+	OptionalValue string
+	OmitEmptyValue string ` + "`json:\"omitEmptyValue,omitempty\"`" + `
+	// +required
+	RequiredPointer *string
+	// +required
+	RequiredOmitEmptyValue string ` + "`json:\"requiredOmitEmptyValue,omitempty\"`" + `
+	// omitempty slices and maps are never required
+	// +required
+	RequiredOmitEmptySlice []string ` + "`json:\"requiredOmitEmptySlice,omitempty\"`" + `
+	OmitEmptyMap map[string]string ` + "`json:\"omitEmptyMap,omitempty\"`" + `
+	OmitEmptyAliasSlice Strings ` + "`json:\"omitEmptyAliasSlice,omitempty\"`" + `
+	Slice []string
+}
+
+type Strings []string
+	`
+	for _, test := range []struct {
+		name              string
+		requiredByDefault *bool
+		expected          string
+		expectedByDefault string
+	}{
+		{
+			name:     "unset",
+			expected: `Required: []string{"Value","Pointer","RequiredPointer","Slice"},`,
+		},
+		{
+			name:              "true",
+			requiredByDefault: func(b bool) *bool { return &b }(true),
+			expected:          `Required: []string{"Value","RequiredPointer","requiredOmitEmptyValue","Slice"},`,
+			expectedByDefault: `RequiredByDefault: []string{"Value","Slice"},`,
+		},
+		{
+			name:              "false",
+			requiredByDefault: func(b bool) *bool { return &b }(false),
+			expected:          `Required: []string{"RequiredPointer","requiredOmitEmptyValue"},`,
+			expectedByDefault: `RequiredByDefault: []string{"Value","Slice"},`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriterWithOptions(t, code, typeWriterOptions{requiredByDefault: test.requiredByDefault})
+			if callErr != nil {
+				t.Fatal(callErr)
+			}
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+			assert.Contains(funcBuffer.String(), "\n"+test.expected+"\n")
+			if test.expectedByDefault == "" {
+				assert.NotContains(funcBuffer.String(), "RequiredByDefault")
+			} else {
+				assert.Contains(funcBuffer.String(), "\n"+test.expectedByDefault+"\n")
+			}
+		})
+	}
+}
+
+func TestNullablePointers(t *testing.T) {
+	code := `
+package foo
+
+// Blah mixes pointer and value fields.
+type Blah struct {
+	// A string pointer
+	StringPointer *string
+	// An int pointer
+	// +k8s:openapi-gen=x-kubernetes-int-tag:int_test
+	IntPointer *int
+	// A string value
+	String string
+	// A struct pointer
+	StructPointer *Blah
+}
+	`
+	expected := func(nullable bool) string {
+		stringExtensions, intNullable := "", ""
+		if nullable {
+			stringExtensions = "VendorExtensible: spec.VendorExtensible{\nExtensions: spec.Extensions{\n\"x-nullable\": true,\n},\n},\n"
+			intNullable = "\"x-nullable\": true,\n"
+		}
+		return `func schema_base_foo_Blah(ref common.ReferenceCallback) common.OpenAPIDefinition {
+return common.OpenAPIDefinition{
+Schema: spec.Schema{
+SchemaProps: spec.SchemaProps{
+Description: "Blah mixes pointer and value fields.",
+Type: []string{"object"},
+Properties: map[string]spec.Schema{
+"StringPointer": {
+` + stringExtensions + `SchemaProps: spec.SchemaProps{
+Description: "A string pointer",
+Type: []string{"string"},
+Format: "",
+},
+},
+"IntPointer": {
+VendorExtensible: spec.VendorExtensible{
+Extensions: spec.Extensions{
+"x-kubernetes-int-tag": "int_test",
+` + intNullable + `},
+},
+SchemaProps: spec.SchemaProps{
+Description: "An int pointer",
+Type: []string{"integer"},
+Format: "int32",
+},
+},
+"String": {
+SchemaProps: spec.SchemaProps{
+Description: "A string value",
+Default: "",
+Type: []string{"string"},
+Format: "",
+},
+},
+"StructPointer": {
+SchemaProps: spec.SchemaProps{
+Description: "A struct pointer",
+Ref: ref("base/foo.Blah"),
+},
+},
+},
+Required: []string{"StringPointer","IntPointer","String","StructPointer"},
+},
+},
+Dependencies: []string{
+"base/foo.Blah",},
+}
+}
+
+`
+	}
+
+	for _, test := range []struct {
+		name             string
+		nullablePointers bool
+	}{
+		{name: "disabled"},
+		{name: "enabled", nullablePointers: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			callErr, funcErr, assert, _, funcBuffer := testOpenAPITypeWriterWithOptions(t, code, typeWriterOptions{nullablePointers: test.nullablePointers})
+			if callErr != nil {
+				t.Fatal(callErr)
+			}
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+			assert.Equal(expected(test.nullablePointers), funcBuffer.String())
+		})
+	}
+}
+
 func TestNestedLists(t *testing.T) {
 	callErr, funcErr, assert, callBuffer, funcBuffer := testOpenAPITypeWriter(t, `
 package foo
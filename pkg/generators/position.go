@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+// markerError is an error in a marker tag of the comments of a type, or of one of its members.
+// It is located by the type writer when reported, see openAPITypeWriter.locate.
+type markerError struct {
+	// parent is the type holding the marker, or holding the member holding it.
+	parent *types.Type
+	// member is the name of the member holding the marker, empty for the markers of the type.
+	member string
+	tag    string
+	err    error
+}
+
+func (e *markerError) Error() string {
+	return e.err.Error()
+}
+
+// markerErrors turns errs into errors in the marker tag of the member of parent.
+func markerErrors(parent *types.Type, member, tag string, errs []error) []error {
+	ret := make([]error, 0, len(errs))
+	for _, err := range errs {
+		ret = append(ret, &markerError{parent: parent, member: member, tag: tag, err: err})
+	}
+	return ret
+}
+
+// locate prefixes marker errors with the position of their marker, if known. Other errors are
+// returned unchanged.
+func (g openAPITypeWriter) locate(err error) error {
+	e, ok := err.(*markerError)
+	if !ok {
+		return err
+	}
+	if pos, ok := g.options.markers.find(e.parent, e.member, e.tag); ok {
+		return fmt.Errorf("%v: %v", pos, e.err)
+	}
+	return e.err
+}
+
+// markerError returns err located at the marker tag of member m of parent.
+func (g openAPITypeWriter) markerError(parent *types.Type, m *types.Member, tag string, err error) error {
+	return g.locate(&markerError{parent: parent, member: m.Name, tag: tag, err: err})
+}
+
+// markerPositions records the positions of the comment lines of the types of packages and of their
+// members, so that marker errors can report them: the types of gengo do not record source positions.
+type markerPositions struct {
+	// comments maps "<package>.<type>" and "<package>.<type>.<member>" to their comment lines.
+	comments map[string][]commentLine
+}
+
+type commentLine struct {
+	text string
+	pos  token.Position
+}
+
+func newMarkerPositions() *markerPositions {
+	return &markerPositions{comments: map[string][]commentLine{}}
+}
+
+// addPackage records the comment lines of the types of pkg and of their members. Packages without
+// sources, e.g. added as single files, and sources which fail to parse are skipped: positions are
+// only reported when known.
+func (p *markerPositions) addPackage(pkg *types.Package) {
+	if pkg == nil || pkg.SourcePath == "" {
+		return
+	}
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, pkg.SourcePath, notTest, parser.ParseComments)
+	if err != nil {
+		return
+	}
+	for _, astPkg := range pkgs {
+		for _, f := range astPkg.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					key := pkg.Path + "." + ts.Name.Name
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					p.addComments(key, fset, doc)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						for _, name := range fieldNames(field) {
+							p.addComments(key+"."+name, fset, field.Doc)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func (p *markerPositions) addComments(key string, fset *token.FileSet, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		pos := fset.Position(c.Pos())
+		if !strings.HasPrefix(c.Text, "/*") {
+			p.comments[key] = append(p.comments[key], commentLine{text: strings.TrimPrefix(c.Text, "//"), pos: pos})
+			continue
+		}
+		for i, line := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/"), "\n") {
+			linePos := pos
+			if i > 0 {
+				linePos.Line += i
+				linePos.Column = 1
+			}
+			p.comments[key] = append(p.comments[key], commentLine{text: line, pos: linePos})
+		}
+	}
+}
+
+// find returns the position of the comment line holding the marker tag on the member of parent, or on
+// parent itself if member is empty.
+func (p *markerPositions) find(parent *types.Type, member, tag string) (token.Position, bool) {
+	if p == nil || parent == nil {
+		return token.Position{}, false
+	}
+	key := parent.Name.Package + "." + parent.Name.Name
+	if member != "" {
+		key += "." + member
+	}
+	for _, line := range p.comments[key] {
+		text := strings.TrimSpace(line.text)
+		if text == "+"+tag || strings.HasPrefix(text, "+"+tag+"=") {
+			return line.pos, true
+		}
+	}
+	return token.Position{}, false
+}
+
+// fieldNames returns the names of the members declared by field, i.e. the name of its type if it is
+// embedded.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		if name := embeddedName(field.Type); name != "" {
+			return []string{name}
+		}
+		return nil
+	}
+	names := make([]string, 0, len(field.Names))
+	for _, n := range field.Names {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+// embeddedName returns the name of an embedded field with type expression e.
+func embeddedName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
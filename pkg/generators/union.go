@@ -153,18 +153,18 @@ func parseUnionStruct(t *types.Type) (*union, []error) {
 			continue
 		}
 		if shouldInlineMembers(&m) {
-			errors = append(errors, fmt.Errorf("union structures can't have embedded fields: %v.%v", t.Name, m.Name))
+			errors = append(errors, &markerError{parent: t, tag: tagUnionMember, err: fmt.Errorf("union structures can't have embedded fields: %v.%v", t.Name, m.Name)})
 			continue
 		}
 		if types.ExtractCommentTags("+", m.CommentLines)[tagUnionDeprecated] != nil {
-			errors = append(errors, fmt.Errorf("union struct can't have unionDeprecated members: %v.%v", t.Name, m.Name))
+			errors = append(errors, &markerError{parent: t, member: m.Name, tag: tagUnionDeprecated, err: fmt.Errorf("union struct can't have unionDeprecated members: %v.%v", t.Name, m.Name)})
 			continue
 		}
 		if types.ExtractCommentTags("+", m.CommentLines)[tagUnionDiscriminator] != nil {
-			errors = append(errors, u.setDiscriminator(jsonName)...)
+			errors = append(errors, markerErrors(t, m.Name, tagUnionDiscriminator, u.setDiscriminator(jsonName))...)
 		} else {
 			if !hasOptionalTag(&m) {
-				errors = append(errors, fmt.Errorf("union members must be optional: %v.%v", t.Name, m.Name))
+				errors = append(errors, &markerError{parent: t, tag: tagUnionMember, err: fmt.Errorf("union members must be optional: %v.%v", t.Name, m.Name)})
 			}
 			u.addMember(jsonName, m.Name)
 		}
@@ -187,15 +187,15 @@ func parseUnionMembers(t *types.Type) (*union, []error) {
 			continue
 		}
 		if types.ExtractCommentTags("+", m.CommentLines)[tagUnionDiscriminator] != nil {
-			errors = append(errors, u.setDiscriminator(jsonName)...)
+			errors = append(errors, markerErrors(t, m.Name, tagUnionDiscriminator, u.setDiscriminator(jsonName))...)
 		}
 		if types.ExtractCommentTags("+", m.CommentLines)[tagUnionMember] != nil {
-			errors = append(errors, fmt.Errorf("union tag is not accepted on struct members: %v.%v", t.Name, m.Name))
+			errors = append(errors, &markerError{parent: t, member: m.Name, tag: tagUnionMember, err: fmt.Errorf("union tag is not accepted on struct members: %v.%v", t.Name, m.Name)})
 			continue
 		}
 		if types.ExtractCommentTags("+", m.CommentLines)[tagUnionDeprecated] != nil {
 			if !hasOptionalTag(&m) {
-				errors = append(errors, fmt.Errorf("union members must be optional: %v.%v", t.Name, m.Name))
+				errors = append(errors, &markerError{parent: t, member: m.Name, tag: tagUnionDeprecated, err: fmt.Errorf("union members must be optional: %v.%v", t.Name, m.Name)})
 			}
 			u.addMember(jsonName, m.Name)
 		}
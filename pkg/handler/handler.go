@@ -19,6 +19,7 @@ package handler
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"crypto/sha512"
 	"fmt"
 	"mime"
@@ -39,7 +40,12 @@ import (
 )
 
 const (
-	jsonExt = ".json"
+	jsonExt     = ".json"
+	checksumExt = ".sha256"
+
+	// checksumHeader carries the SHA-256 checksum of the served document, see
+	// RegisterOpenAPIVersionedServiceWithChecksum.
+	checksumHeader = "X-OpenAPI-Checksum"
 
 	mimeJson = "application/json"
 	// TODO(mehdy): change @68f4ded to a version tag when gnostic add version tags.
@@ -62,6 +68,19 @@ type OpenAPIService struct {
 	specBytesETag string
 	specPbETag    string
 	specPbGzETag  string
+
+	specBytesChecksum string
+	specPbChecksum    string
+
+	observer Observer
+}
+
+// Observer is notified of the requests served by an OpenAPIService, e.g. to record metrics.
+type Observer interface {
+	// OnServe is called once a spec has been served in the given format (the media type of the response).
+	// cacheHit is true if the client already had the spec, i.e. it got a 304 (Not Modified) response.
+	// bytes is the size of the uncompressed response body and d the time spent serving the request.
+	OnServe(format string, cacheHit bool, bytes int, d time.Duration)
 }
 
 func init() {
@@ -74,6 +93,10 @@ func computeETag(data []byte) string {
 	return fmt.Sprintf("\"%X\"", sha512.Sum512(data))
 }
 
+func computeChecksum(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
 // NewOpenAPIService builds an OpenAPIService starting with the given spec.
 func NewOpenAPIService(spec *spec.Swagger) (*OpenAPIService, error) {
 	o := &OpenAPIService{}
@@ -83,16 +106,29 @@ func NewOpenAPIService(spec *spec.Swagger) (*OpenAPIService, error) {
 	return o, nil
 }
 
-func (o *OpenAPIService) getSwaggerBytes() ([]byte, string, time.Time) {
+// SetObserver sets the observer notified of the requests served. A nil observer disables the notifications.
+func (o *OpenAPIService) SetObserver(observer Observer) {
+	o.rwMutex.Lock()
+	defer o.rwMutex.Unlock()
+	o.observer = observer
+}
+
+func (o *OpenAPIService) getObserver() Observer {
+	o.rwMutex.RLock()
+	defer o.rwMutex.RUnlock()
+	return o.observer
+}
+
+func (o *OpenAPIService) getSwaggerBytes() ([]byte, string, string, time.Time) {
 	o.rwMutex.RLock()
 	defer o.rwMutex.RUnlock()
-	return o.specBytes, o.specBytesETag, o.lastModified
+	return o.specBytes, o.specBytesETag, o.specBytesChecksum, o.lastModified
 }
 
-func (o *OpenAPIService) getSwaggerPbBytes() ([]byte, string, time.Time) {
+func (o *OpenAPIService) getSwaggerPbBytes() ([]byte, string, string, time.Time) {
 	o.rwMutex.RLock()
 	defer o.rwMutex.RUnlock()
-	return o.specPb, o.specPbETag, o.lastModified
+	return o.specPb, o.specPbETag, o.specPbChecksum, o.lastModified
 }
 
 func (o *OpenAPIService) getSwaggerPbGzBytes() ([]byte, string, time.Time) {
@@ -116,6 +152,9 @@ func (o *OpenAPIService) UpdateSpec(openapiSpec *spec.Swagger) (err error) {
 	specPbETag := computeETag(specPb)
 	specPbGzETag := computeETag(specPbGz)
 
+	specBytesChecksum := computeChecksum(specBytes)
+	specPbChecksum := computeChecksum(specPb)
+
 	lastModified := time.Now()
 
 	o.rwMutex.Lock()
@@ -127,6 +166,8 @@ func (o *OpenAPIService) UpdateSpec(openapiSpec *spec.Swagger) (err error) {
 	o.specBytesETag = specBytesETag
 	o.specPbETag = specPbETag
 	o.specPbGzETag = specPbGzETag
+	o.specBytesChecksum = specBytesChecksum
+	o.specPbChecksum = specPbChecksum
 	o.lastModified = lastModified
 
 	return nil
@@ -203,10 +244,31 @@ func RegisterOpenAPIVersionedService(spec *spec.Swagger, servePath string, handl
 
 // RegisterOpenAPIVersionedService registers a handler to provide access to provided swagger spec.
 func (o *OpenAPIService) RegisterOpenAPIVersionedService(servePath string, handler common.PathHandler) error {
+	o.registerOpenAPIVersionedService(servePath, handler, false)
+	return nil
+}
+
+// RegisterOpenAPIVersionedServiceWithChecksum registers a handler to provide access to provided swagger spec,
+// like RegisterOpenAPIVersionedService. In addition, the hex encoded SHA-256 checksum of the JSON document is
+// served at servePath + ".sha256" (e.g. /openapi/v2.sha256), and every response of the main handler carries
+// the checksum of the served document in the X-OpenAPI-Checksum header. Checksums are recomputed on UpdateSpec.
+func (o *OpenAPIService) RegisterOpenAPIVersionedServiceWithChecksum(servePath string, handler common.PathHandler) error {
+	o.registerOpenAPIVersionedService(servePath, handler, true)
+	handler.Handle(servePath+checksumExt, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _, checksum, _ := o.getSwaggerBytes()
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(checksum))
+		}),
+	)
+	return nil
+}
+
+func (o *OpenAPIService) registerOpenAPIVersionedService(servePath string, handler common.PathHandler, withChecksum bool) {
 	accepted := []struct {
 		Type           string
 		SubType        string
-		GetDataAndETag func() ([]byte, string, time.Time)
+		GetDataAndETag func() ([]byte, string, string, time.Time)
 	}{
 		{"application", "json", o.getSwaggerBytes},
 		{"application", "com.github.proto-openapi.spec.v2@v1.0+protobuf", o.getSwaggerPbBytes},
@@ -230,8 +292,19 @@ func (o *OpenAPIService) RegisterOpenAPIVersionedService(servePath string, handl
 					}
 
 					// serve the first matching media type in the sorted clause list
-					data, etag, lastModified := accepts.GetDataAndETag()
+					if observer := o.getObserver(); observer != nil {
+						start := time.Now()
+						ow := &observedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+						defer func() {
+							observer.OnServe(accepts.Type+"/"+accepts.SubType, ow.status == http.StatusNotModified, ow.bytes, time.Since(start))
+						}()
+						w = ow
+					}
+					data, etag, checksum, lastModified := accepts.GetDataAndETag()
 					w.Header().Set("Etag", etag)
+					if withChecksum {
+						w.Header().Set(checksumHeader, checksum)
+					}
 					// ServeContent will take care of caching using eTag.
 					http.ServeContent(w, r, servePath, lastModified, bytes.NewReader(data))
 					return
@@ -242,8 +315,24 @@ func (o *OpenAPIService) RegisterOpenAPIVersionedService(servePath string, handl
 			return
 		}),
 	))
+}
 
-	return nil
+// observedResponseWriter records the status and the size of a response for the Observer.
+type observedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *observedResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *observedResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
 }
 
 // BuildAndRegisterOpenAPIVersionedService builds the spec and registers a handler to provide access to it.
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"io/ioutil"
 	"math"
 	"net/http"
@@ -8,6 +10,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	json "github.com/json-iterator/go"
@@ -99,6 +102,138 @@ func TestRegisterOpenAPIVersionedService(t *testing.T) {
 	}
 }
 
+func TestRegisterOpenAPIVersionedServiceWithChecksum(t *testing.T) {
+	var s spec.Swagger
+	if err := s.UnmarshalJSON(returnedSwagger); err != nil {
+		t.Fatalf("Unexpected error in unmarshalling SwaggerJSON: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	o, err := NewOpenAPIService(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = o.RegisterOpenAPIVersionedServiceWithChecksum("/openapi/v2", mux); err != nil {
+		t.Fatalf("Unexpected error in register OpenAPI versioned service: %v", err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := server.Client()
+
+	get := func(path, accept string) (*http.Response, []byte) {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error in creating new request: %v", err)
+		}
+		req.Header.Add("Accept", accept)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error in serving HTTP request: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Unexpected error in reading response body: %v", err)
+		}
+		return resp, body
+	}
+	checkChecksums := func() string {
+		var jsonChecksum string
+		for _, accept := range []string{"application/json", "application/com.github.proto-openapi.spec.v2@v1.0+protobuf"} {
+			resp, body := get("/openapi/v2", accept)
+			if resp.StatusCode != 200 {
+				t.Fatalf("Accept: %v: Unexpected response status code, want: 200, got: %v", accept, resp.StatusCode)
+			}
+			want := fmt.Sprintf("%x", sha256.Sum256(body))
+			if got := resp.Header.Get("X-OpenAPI-Checksum"); got != want {
+				t.Errorf("Accept: %v: Unexpected checksum header, want: %v, got: %v", accept, want, got)
+			}
+			if accept == "application/json" {
+				jsonChecksum = want
+			}
+		}
+		resp, body := get("/openapi/v2.sha256", "")
+		if resp.StatusCode != 200 {
+			t.Fatalf("Unexpected response status code for checksum, want: 200, got: %v", resp.StatusCode)
+		}
+		if string(body) != jsonChecksum {
+			t.Errorf("Unexpected checksum, want: %v, got: %s", jsonChecksum, body)
+		}
+		return jsonChecksum
+	}
+
+	before := checkChecksums()
+	s.Info.Version = "v1.12.0"
+	if err := o.UpdateSpec(&s); err != nil {
+		t.Fatal(err)
+	}
+	after := checkChecksums()
+	if before == after {
+		t.Errorf("Expected checksum to change after the spec was updated")
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	var s spec.Swagger
+	if err := s.UnmarshalJSON(returnedSwagger); err != nil {
+		t.Fatalf("Unexpected error in unmarshalling SwaggerJSON: %v", err)
+	}
+
+	// a restarted server serving the same spec must compute the same ETags
+	etags := map[string]string{}
+	for i := 0; i < 2; i++ {
+		mux := http.NewServeMux()
+		o, err := NewOpenAPIService(&s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = o.RegisterOpenAPIVersionedService("/openapi/v2", mux); err != nil {
+			t.Fatalf("Unexpected error in register OpenAPI versioned service: %v", err)
+		}
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		for _, accept := range []string{"application/json", "application/com.github.proto-openapi.spec.v2@v1.0+protobuf"} {
+			get := func(etag string) *http.Response {
+				req, err := http.NewRequest("GET", server.URL+"/openapi/v2", nil)
+				if err != nil {
+					t.Fatalf("Accept: %v: Unexpected error in creating new request: %v", accept, err)
+				}
+				req.Header.Add("Accept", accept)
+				if etag != "" {
+					req.Header.Add("If-None-Match", etag)
+				}
+				resp, err := server.Client().Do(req)
+				if err != nil {
+					t.Fatalf("Accept: %v: Unexpected error in serving HTTP request: %v", accept, err)
+				}
+				resp.Body.Close()
+				return resp
+			}
+
+			resp := get("")
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Accept: %v: Unexpected response status code, want: 200, got: %v", accept, resp.StatusCode)
+			}
+			etag := resp.Header.Get("Etag")
+			if previous, ok := etags[accept]; ok && previous != etag {
+				t.Errorf("Accept: %v: ETag changed across servers, was %v, got %v", accept, previous, etag)
+			}
+			etags[accept] = etag
+
+			if resp = get(etag); resp.StatusCode != http.StatusNotModified {
+				t.Errorf("Accept: %v: Unexpected response status code, want: 304, got: %v", accept, resp.StatusCode)
+			}
+			if resp = get(`"other"`); resp.StatusCode != http.StatusOK {
+				t.Errorf("Accept: %v: Unexpected response status code, want: 200, got: %v", accept, resp.StatusCode)
+			}
+		}
+	}
+	if etags["application/json"] == etags["application/com.github.proto-openapi.spec.v2@v1.0+protobuf"] {
+		t.Errorf("Expected different ETags for the JSON and protobuf representations")
+	}
+}
+
 func TestJsonToYAML(t *testing.T) {
 	intOrInt64 := func(i64 int64) interface{} {
 		if i := int(i64); i64 == int64(i) {
@@ -177,3 +312,83 @@ func TestToProtoBinary(t *testing.T) {
 	}
 	// TODO: add some kind of roundtrip test here
 }
+
+type recordedServe struct {
+	format   string
+	cacheHit bool
+	bytes    int
+}
+
+type recordingObserver struct {
+	serves []recordedServe
+}
+
+func (r *recordingObserver) OnServe(format string, cacheHit bool, bytes int, d time.Duration) {
+	r.serves = append(r.serves, recordedServe{format: format, cacheHit: cacheHit, bytes: bytes})
+}
+
+func TestOpenAPIServiceObserver(t *testing.T) {
+	var s spec.Swagger
+	if err := s.UnmarshalJSON(returnedSwagger); err != nil {
+		t.Fatalf("Unexpected error in unmarshalling SwaggerJSON: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	o, err := NewOpenAPIService(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observer := &recordingObserver{}
+	o.SetObserver(observer)
+	if err = o.RegisterOpenAPIVersionedService("/openapi/v2", mux); err != nil {
+		t.Fatalf("Unexpected error in register OpenAPI versioned service: %v", err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := server.Client()
+
+	get := func(etag string) *http.Response {
+		req, err := http.NewRequest("GET", server.URL+"/openapi/v2", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error in creating new request: %v", err)
+		}
+		req.Header.Add("Accept", "application/json")
+		if etag != "" {
+			req.Header.Add("If-None-Match", etag)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error in serving HTTP request: %v", err)
+		}
+		defer resp.Body.Close()
+		if _, err := ioutil.ReadAll(resp.Body); err != nil {
+			t.Fatalf("Unexpected error in reading response body: %v", err)
+		}
+		return resp
+	}
+
+	resp := get("")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected response status code, want: 200, got: %v", resp.StatusCode)
+	}
+	resp = get(resp.Header.Get("Etag"))
+	if resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("Unexpected response status code, want: 304, got: %v", resp.StatusCode)
+	}
+
+	specBytes, _, _, _ := o.getSwaggerBytes()
+	want := []recordedServe{
+		{format: "application/json", cacheHit: false, bytes: len(specBytes)},
+		{format: "application/json", cacheHit: true, bytes: 0},
+	}
+	if !reflect.DeepEqual(want, observer.serves) {
+		t.Errorf("Unexpected observed requests, want: %v, got: %v", want, observer.serves)
+	}
+
+	// no notification once the observer is removed
+	o.SetObserver(nil)
+	get("")
+	if len(observer.serves) != 2 {
+		t.Errorf("Unexpected observed requests after the observer was removed: %v", observer.serves)
+	}
+}
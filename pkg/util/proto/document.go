@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
 	"gopkg.in/yaml.v2"
@@ -56,10 +57,23 @@ func VendorExtensionToMap(e []*openapi_v2.NamedAny) map[string]interface{} {
 	return values
 }
 
+// groupVersionKindExtensionKey is the extension listing the Kubernetes
+// groups, versions and kinds of a model.
+const groupVersionKindExtensionKey = "x-kubernetes-group-version-kind"
+
 // Definitions is an implementation of `Models`. It looks for
 // models in an openapi Schema.
 type Definitions struct {
 	models map[string]Schema
+
+	// gvkIndexOnce guards the lazy construction of gvkIndex, see LookupByGVK.
+	gvkIndexOnce sync.Once
+	gvkIndex     map[gvk]Schema
+}
+
+// gvk is a Kubernetes group, version and kind.
+type gvk struct {
+	group, version, kind string
 }
 
 var _ Models = &Definitions{}
@@ -325,6 +339,72 @@ func (d *Definitions) LookupModel(model string) Schema {
 	return d.models[model]
 }
 
+// LookupByGVK is public through the interface of Models. It returns the
+// schema of the model whose x-kubernetes-group-version-kind extension
+// lists the given group, version and kind. The index of the models by
+// group, version and kind is built on first use.
+func (d *Definitions) LookupByGVK(group, version, kind string) (Schema, bool) {
+	d.gvkIndexOnce.Do(d.buildGVKIndex)
+	schema, ok := d.gvkIndex[gvk{group: group, version: version, kind: kind}]
+	return schema, ok
+}
+
+func (d *Definitions) buildGVKIndex() {
+	d.gvkIndex = map[gvk]Schema{}
+	// Visit the models in order, so that the first model wins if several
+	// list the same group, version and kind.
+	for _, model := range d.ListModels() {
+		schema := d.models[model]
+		if schema == nil {
+			continue
+		}
+		gvks, ok := schema.GetExtensions()[groupVersionKindExtensionKey].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range gvks {
+			key, ok := parseGVK(item)
+			if !ok {
+				continue
+			}
+			if _, exists := d.gvkIndex[key]; !exists {
+				d.gvkIndex[key] = schema
+			}
+		}
+	}
+}
+
+// parseGVK reads a group, version and kind from an item of the
+// x-kubernetes-group-version-kind extension.
+func parseGVK(item interface{}) (gvk, bool) {
+	fields := map[string]string{}
+	switch m := item.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			ks, kok := k.(string)
+			vs, vok := v.(string)
+			if kok && vok {
+				fields[ks] = vs
+			}
+		}
+	case map[string]interface{}:
+		for k, v := range m {
+			if vs, ok := v.(string); ok {
+				fields[k] = vs
+			}
+		}
+	default:
+		return gvk{}, false
+	}
+	// the core group is empty, and may be omitted
+	version, vok := fields["version"]
+	kind, kok := fields["kind"]
+	if !vok || !kok {
+		return gvk{}, false
+	}
+	return gvk{group: fields["group"], version: version, kind: kind}, true
+}
+
 func (d *Definitions) ListModels() []string {
 	models := []string{}
 
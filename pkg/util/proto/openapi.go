@@ -40,6 +40,9 @@ const (
 type Models interface {
 	LookupModel(string) Schema
 	ListModels() []string
+	// LookupByGVK returns the schema of the model tagged with the given
+	// Kubernetes group, version and kind, if any.
+	LookupByGVK(group, version, kind string) (Schema, bool)
 }
 
 // SchemaVisitor is an interface that you need to implement if you want
@@ -19,6 +19,7 @@ package proto_test
 import (
 	"path/filepath"
 
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -263,3 +264,51 @@ var _ = Describe("Path", func() {
 		Expect(field.Get()).To(Equal([]string{"key", "[12]", ".subKey"}))
 	})
 })
+
+var _ = Describe("Looking up models by group, version and kind", func() {
+	var models proto.Models
+	BeforeEach(func() {
+		doc, err := openapi_v2.ParseDocument([]byte(`
+swagger: "2.0"
+info:
+  title: test
+  version: v1
+paths: {}
+definitions:
+  io.k8s.api.apps.v1.Deployment:
+    type: object
+    x-kubernetes-group-version-kind:
+    - group: apps
+      version: v1
+      kind: Deployment
+  io.k8s.api.core.v1.Pod:
+    type: object
+    x-kubernetes-group-version-kind:
+    - group: ""
+      version: v1
+      kind: Pod
+  io.k8s.api.core.v1.PodSpec:
+    type: object
+`))
+		Expect(err).To(BeNil())
+		models, err = proto.NewOpenAPIData(doc)
+		Expect(err).To(BeNil())
+	})
+
+	It("should find the models tagged with a group, version and kind", func() {
+		deployment, found := models.LookupByGVK("apps", "v1", "Deployment")
+		Expect(found).To(BeTrue())
+		Expect(deployment.GetPath().Get()).To(Equal([]string{"io.k8s.api.apps.v1.Deployment"}))
+
+		pod, found := models.LookupByGVK("", "v1", "Pod")
+		Expect(found).To(BeTrue())
+		Expect(pod.GetPath().Get()).To(Equal([]string{"io.k8s.api.core.v1.Pod"}))
+	})
+
+	It("should not find unknown groups, versions and kinds", func() {
+		_, found := models.LookupByGVK("apps", "v1beta1", "Deployment")
+		Expect(found).To(BeFalse())
+		_, found = models.LookupByGVK("", "v1", "PodSpec")
+		Expect(found).To(BeFalse())
+	})
+})
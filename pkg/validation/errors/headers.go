@@ -22,6 +22,14 @@ type Validation struct {
 	Value   interface{}
 	message string
 	Values  []interface{}
+
+	// SchemaPath locates the schema which reported the error, as a JSON pointer fragment
+	// (e.g. "#/properties/spec/allOf/0"). It is set by the schema validator.
+	SchemaPath string
+
+	// InvalidSchema is set when the schema rather than the value is invalid, e.g. for a pattern
+	// which cannot be compiled, whatever the code of the error.
+	InvalidSchema bool
 }
 
 func (e *Validation) Error() string {
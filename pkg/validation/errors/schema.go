@@ -64,6 +64,11 @@ const (
 	failedAllPatternProps     = "%s.%s in %s failed all pattern properties"
 	failedAllPatternPropsNoIn = "%s.%s failed all pattern properties"
 	multipleOfMustBePositive  = "factor MultipleOf declared for %s must be positive: %v"
+	aggregatedFail            = "%d errors at %s, e.g. %s (at %s)"
+	ruleFail                  = "%s in %s failed rule: %s"
+	ruleFailNoIn              = "%s failed rule: %s"
+	invalidRule               = "%s in %s has an invalid rule %q: %v"
+	invalidRuleNoIn           = "%s has an invalid rule %q: %v"
 )
 
 // All code responses can be used to differentiate errors for different handling
@@ -91,6 +96,8 @@ const (
 	UnallowedPropertyCode
 	FailedAllPatternPropsCode
 	MultipleOfMustBePositiveCode
+	FailedRuleCode
+	InvalidRuleCode
 )
 
 // CompositeError is an error that groups several errors together
@@ -554,6 +561,14 @@ func FailedPattern(name, in, pattern string, value interface{}) *Validation {
 	}
 }
 
+// InvalidPattern error for when the regex pattern of a schema cannot be compiled,
+// i.e. when the schema rather than the value is invalid. It has the PatternFailCode.
+func InvalidPattern(name, in, pattern string, err error, value interface{}) *Validation {
+	v := FailedPattern(name, in, fmt.Sprintf("%s, but pattern is invalid: %s", pattern, err.Error()), value)
+	v.InvalidSchema = true
+	return v
+}
+
 // MultipleOfMustBePositive error for when a
 // multipleOf factor is negative
 func MultipleOfMustBePositive(name, in string, factor interface{}) *Validation {
@@ -565,3 +580,57 @@ func MultipleOfMustBePositive(name, in string, factor interface{}) *Validation {
 		message: fmt.Sprintf(multipleOfMustBePositive, name, factor),
 	}
 }
+
+// Aggregated error standing for count errors with the same code, reported at paths matching
+// the same pattern. The first error is given as a sample, together with a few sample paths.
+func Aggregated(pattern, in string, code int32, count int, sample error, samplePaths []string) *Validation {
+	values := make([]interface{}, 0, len(samplePaths))
+	for _, p := range samplePaths {
+		values = append(values, p)
+	}
+	return &Validation{
+		code:    code,
+		Name:    pattern,
+		In:      in,
+		Value:   count,
+		Values:  values,
+		message: fmt.Sprintf(aggregatedFail, count, pattern, sample, strings.Join(samplePaths, ", ")),
+	}
+}
+
+// FailedRule error for when a value does not satisfy a x-kubernetes-validations rule.
+// The message is the message of the rule if it has one, the rule itself otherwise.
+func FailedRule(name, in, message string, value interface{}) *Validation {
+	var msg string
+	if in == "" {
+		msg = fmt.Sprintf(ruleFailNoIn, name, message)
+	} else {
+		msg = fmt.Sprintf(ruleFail, name, in, message)
+	}
+
+	return &Validation{
+		code:    FailedRuleCode,
+		Name:    name,
+		In:      in,
+		Value:   value,
+		message: msg,
+	}
+}
+
+// InvalidRule error for when a x-kubernetes-validations rule cannot be compiled.
+func InvalidRule(name, in, rule string, err error) *Validation {
+	var msg string
+	if in == "" {
+		msg = fmt.Sprintf(invalidRuleNoIn, name, rule, err)
+	} else {
+		msg = fmt.Sprintf(invalidRule, name, in, rule, err)
+	}
+
+	return &Validation{
+		code:    InvalidRuleCode,
+		Name:    name,
+		In:      in,
+		Value:   rule,
+		message: msg,
+	}
+}
@@ -390,6 +390,13 @@ func TestSchemaErrors(t *testing.T) {
 	//tooFewPropertiesNoIn      = "%s should have at least %d properties"
 	assert.Equal(t, "path should have at least 10 properties", err.Error())
 
+	err = Aggregated("items.*.name", "body", RequiredFailCode, 12, Required("items.0.name", "body"), []string{"items.0.name", "items.1.name"})
+	assert.Error(t, err)
+	assert.EqualValues(t, RequiredFailCode, err.Code())
+	assert.Equal(t, "12 errors at items.*.name, e.g. items.0.name in body is required (at items.0.name, items.1.name)", err.Error())
+	assert.Equal(t, 12, err.Value)
+	assert.Equal(t, []interface{}{"items.0.name", "items.1.name"}, err.Values)
+
 	//func FailedAllPatternProperties(name, in, key string) *Validation {
 	err = FailedAllPatternProperties("path", "body", "key")
 	assert.Error(t, err)
@@ -402,4 +409,24 @@ func TestSchemaErrors(t *testing.T) {
 	assert.EqualValues(t, FailedAllPatternPropsCode, err.Code())
 	//failedAllPatternPropsNoIn = "%s.%s failed all pattern properties"
 	assert.Equal(t, "path.key failed all pattern properties", err.Error())
+	err = FailedRule("path", "body", "must be positive", -1)
+	assert.Error(t, err)
+	assert.EqualValues(t, FailedRuleCode, err.Code())
+	assert.Equal(t, "path in body failed rule: must be positive", err.Error())
+	assert.Equal(t, -1, err.Value)
+
+	err = FailedRule("path", "", "self > 0", -1)
+	assert.Error(t, err)
+	assert.EqualValues(t, FailedRuleCode, err.Code())
+	assert.Equal(t, "path failed rule: self > 0", err.Error())
+
+	err = InvalidRule("path", "body", "self >", fmt.Errorf("syntax error"))
+	assert.Error(t, err)
+	assert.EqualValues(t, InvalidRuleCode, err.Code())
+	assert.Equal(t, `path in body has an invalid rule "self >": syntax error`, err.Error())
+
+	err = InvalidRule("path", "", "self >", fmt.Errorf("syntax error"))
+	assert.Error(t, err)
+	assert.EqualValues(t, InvalidRuleCode, err.Code())
+	assert.Equal(t, `path has an invalid rule "self >": syntax error`, err.Error())
 }
@@ -84,7 +84,8 @@ func (v *VendorExtensible) AddExtension(key string, value interface{}) {
 	v.Extensions.Add(key, value)
 }
 
-// MarshalJSON marshals the extensions to json
+// MarshalJSON marshals the extensions to json. The extensions are sorted by key, as are
+// the keys of the maps in their values, so that equal extensions marshal to the same bytes.
 func (v VendorExtensible) MarshalJSON() ([]byte, error) {
 	toser := make(map[string]interface{})
 	for k, v := range v.Extensions {
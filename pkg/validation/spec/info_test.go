@@ -68,3 +68,42 @@ func TestIntegrationInfo_Deserialize(t *testing.T) {
 		assert.EqualValues(t, info, actual)
 	}
 }
+
+func TestExtensionsMarshalDeterministically(t *testing.T) {
+	// extensions with the same content, inserted in opposite orders
+	keys := []string{"x-kubernetes-list-type", "x-b", "x-a", "x-kubernetes-group-version-kind", "x-z", "x-m"}
+	extensions := func(reverse bool) Extensions {
+		e := Extensions{}
+		for i := range keys {
+			k := keys[i]
+			if reverse {
+				k = keys[len(keys)-1-i]
+			}
+			e.Add(k, map[string]interface{}{"value": k, "b": 1, "a": []interface{}{"x", "y"}})
+		}
+		return e
+	}
+
+	marshal := func(reverse bool) [][]byte {
+		e := extensions(reverse)
+		var all [][]byte
+		for _, v := range []interface{}{
+			Schema{VendorExtensible: VendorExtensible{Extensions: e}},
+			Operation{VendorExtensible: VendorExtensible{Extensions: e}},
+			Parameter{VendorExtensible: VendorExtensible{Extensions: e}},
+			Response{VendorExtensible: VendorExtensible{Extensions: e}},
+			Info{VendorExtensible: VendorExtensible{Extensions: e}},
+		} {
+			b, err := json.Marshal(v)
+			assert.NoError(t, err)
+			all = append(all, b)
+		}
+		return all
+	}
+
+	expected := marshal(false)
+	assert.Contains(t, string(expected[0]), `"x-a":{"a":["x","y"],"b":1,"value":"x-a"},"x-b":`)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, marshal(i%2 == 0))
+	}
+}
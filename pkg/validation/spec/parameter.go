@@ -16,6 +16,7 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/go-openapi/swag"
 )
@@ -109,3 +110,63 @@ func (p Parameter) MarshalJSON() ([]byte, error) {
 	}
 	return swag.ConcatJSON(b3, b1, b2, b4, b5), nil
 }
+
+// AsSchema converts a simple parameter, i.e. not in body, to the equivalent schema: its type, format,
+// items, default, example and validations are carried over, as well as its description.
+// It returns an error for body parameters, which have a schema already, for references and for file parameters.
+func (p *Parameter) AsSchema() (*Schema, error) {
+	if p.In == "body" {
+		return nil, fmt.Errorf("parameter %q is a body parameter, use its schema", p.Name)
+	}
+	if p.Ref.String() != "" {
+		return nil, fmt.Errorf("parameter %q is a reference to %s", p.Name, p.Ref.String())
+	}
+	schema, err := simpleSchemaAsSchema(p.SimpleSchema, p.CommonValidations)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %v", p.Name, err)
+	}
+	schema.Description = p.Description
+	return schema, nil
+}
+
+func simpleSchemaAsSchema(simple SimpleSchema, validations CommonValidations) (*Schema, error) {
+	if simple.Type == "file" {
+		return nil, fmt.Errorf("file type has no schema equivalent")
+	}
+	schema := &Schema{
+		SchemaProps: SchemaProps{
+			Nullable:         simple.Nullable,
+			Format:           simple.Format,
+			Default:          simple.Default,
+			Maximum:          validations.Maximum,
+			ExclusiveMaximum: validations.ExclusiveMaximum,
+			Minimum:          validations.Minimum,
+			ExclusiveMinimum: validations.ExclusiveMinimum,
+			MaxLength:        validations.MaxLength,
+			MinLength:        validations.MinLength,
+			Pattern:          validations.Pattern,
+			MaxItems:         validations.MaxItems,
+			MinItems:         validations.MinItems,
+			UniqueItems:      validations.UniqueItems,
+			MultipleOf:       validations.MultipleOf,
+			Enum:             validations.Enum,
+		},
+		SwaggerSchemaProps: SwaggerSchemaProps{
+			Example: simple.Example,
+		},
+	}
+	if simple.Type != "" {
+		schema.Type = StringOrArray{simple.Type}
+	}
+	if simple.Items != nil {
+		if simple.Items.Ref.String() != "" {
+			return nil, fmt.Errorf("items are a reference to %s", simple.Items.Ref.String())
+		}
+		items, err := simpleSchemaAsSchema(simple.Items.SimpleSchema, simple.Items.CommonValidations)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = &SchemaOrArray{Schema: items}
+	}
+	return schema, nil
+}
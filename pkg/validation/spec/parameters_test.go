@@ -97,3 +97,56 @@ func TestIntegrationParameter(t *testing.T) {
 
 	assertParsesJSON(t, parameterJSON, parameter)
 }
+
+func TestParameterAsSchema(t *testing.T) {
+	var intParam Parameter
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"name": "limit",
+		"in": "query",
+		"description": "maximum number of items",
+		"type": "integer",
+		"format": "int32",
+		"minimum": 1,
+		"maximum": 100,
+		"default": 10
+	}`), &intParam))
+	schema, err := intParam.AsSchema()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"description": "maximum number of items",
+		"type": "integer",
+		"format": "int32",
+		"minimum": 1,
+		"maximum": 100,
+		"default": 10
+	}`, mustMarshal(t, schema))
+
+	var arrayParam Parameter
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"name": "colors",
+		"in": "query",
+		"type": "array",
+		"collectionFormat": "csv",
+		"minItems": 1,
+		"uniqueItems": true,
+		"items": {"type": "string", "enum": ["red", "green"], "maxLength": 5}
+	}`), &arrayParam))
+	schema, err = arrayParam.AsSchema()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "array",
+		"minItems": 1,
+		"uniqueItems": true,
+		"items": {"type": "string", "enum": ["red", "green"], "maxLength": 5}
+	}`, mustMarshal(t, schema))
+
+	bodyParam := Parameter{ParamProps: ParamProps{Name: "body", In: "body", Schema: RefSchema("#/definitions/Pet")}}
+	_, err = bodyParam.AsSchema()
+	assert.EqualError(t, err, `parameter "body" is a body parameter, use its schema`)
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return string(b)
+}
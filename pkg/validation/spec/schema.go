@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/go-openapi/swag"
@@ -430,6 +431,64 @@ func (s *Schema) WithExternalDocs(description, url string) *Schema {
 	return s
 }
 
+// MergeExtensions merges the given extensions into the extensions of this schema.
+// Map values are merged recursively and array values are concatenated, skipping the
+// elements already present. Other conflicting values are replaced only if overwrite is true.
+func (s *Schema) MergeExtensions(other Extensions, overwrite bool) {
+	if len(other) == 0 {
+		return
+	}
+	if s.Extensions == nil {
+		s.Extensions = make(Extensions, len(other))
+	}
+	for k, v := range other {
+		key := strings.ToLower(k)
+		if existing, ok := s.Extensions[key]; ok {
+			s.Extensions[key] = mergeExtensionValue(existing, v, overwrite)
+		} else {
+			s.Extensions[key] = v
+		}
+	}
+}
+
+func mergeExtensionValue(dst, src interface{}, overwrite bool) interface{} {
+	switch d := dst.(type) {
+	case map[string]interface{}:
+		if s, ok := src.(map[string]interface{}); ok {
+			merged := make(map[string]interface{}, len(d)+len(s))
+			for k, v := range d {
+				merged[k] = v
+			}
+			for k, v := range s {
+				if existing, ok := merged[k]; ok {
+					merged[k] = mergeExtensionValue(existing, v, overwrite)
+				} else {
+					merged[k] = v
+				}
+			}
+			return merged
+		}
+	case []interface{}:
+		if s, ok := src.([]interface{}); ok {
+			merged := append(make([]interface{}, 0, len(d)+len(s)), d...)
+		next:
+			for _, v := range s {
+				for _, existing := range merged {
+					if reflect.DeepEqual(existing, v) {
+						continue next
+					}
+				}
+				merged = append(merged, v)
+			}
+			return merged
+		}
+	}
+	if overwrite {
+		return src
+	}
+	return dst
+}
+
 // MarshalJSON marshal this to JSON
 func (s Schema) MarshalJSON() ([]byte, error) {
 	b1, err := json.Marshal(s.SchemaProps)
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// EnumErrors lists the enum values which do not match the type of their schema.
+type EnumErrors []string
+
+// Error implements the error interface
+func (e EnumErrors) Error() string {
+	return fmt.Sprintf("invalid enum values: %s", strings.Join(e, "; "))
+}
+
+// ValidateEnum checks that the enum values of the schema and of all its nested schemas
+// are assignable to the type declared by their schema, e.g. that a string enum only has
+// string values. It returns an EnumErrors listing all the mismatches, or nil.
+func (s *Schema) ValidateEnum() error {
+	var errs EnumErrors
+	v := SchemaVisitor{
+		SchemaAt: func(schema *Schema, loc *SchemaLocation) bool {
+			if len(schema.Type) == 0 {
+				return true
+			}
+			for i, value := range schema.Enum {
+				if !schema.acceptsEnumValue(value) {
+					errs = append(errs, fmt.Sprintf("%senum[%d]: %#v is not of type %s", loc.Path, i, value, strings.Join(schema.Type, " or ")))
+				}
+			}
+			return true
+		},
+	}
+	v.Walk(s)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// acceptsEnumValue returns true if v is assignable to one of the types of the schema.
+func (s *Schema) acceptsEnumValue(v interface{}) bool {
+	if v == nil {
+		return s.Nullable || s.Type.Contains("null")
+	}
+	for _, t := range s.Type {
+		if isValueOfType(v, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func isValueOfType(v interface{}, t string) bool {
+	if n, ok := v.(json.Number); ok {
+		switch t {
+		case "number":
+			_, err := n.Float64()
+			return err == nil
+		case "integer":
+			_, err := n.Int64()
+			return err == nil
+		}
+		return false
+	}
+
+	rv := reflect.ValueOf(v)
+	switch t {
+	case "string":
+		return rv.Kind() == reflect.String
+	case "boolean":
+		return rv.Kind() == reflect.Bool
+	case "integer":
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return true
+		case reflect.Float32, reflect.Float64:
+			// numbers decoded from JSON are float64
+			f := rv.Float()
+			return !math.IsInf(f, 0) && f == math.Trunc(f)
+		}
+	case "number":
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	case "array":
+		return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+	case "object":
+		return rv.Kind() == reflect.Map || rv.Kind() == reflect.Struct
+	case "null":
+		return false
+	default:
+		// unknown types are not checked
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEnum(t *testing.T) {
+	t.Run("valid string enum", func(t *testing.T) {
+		assert.NoError(t, StringProperty().WithEnum("a", "b").ValidateEnum())
+	})
+
+	t.Run("integer enum with string value", func(t *testing.T) {
+		err := Int64Property().WithEnum(1, "two", 3.0).ValidateEnum()
+		require.Error(t, err)
+		assert.Equal(t, EnumErrors{`enum[1]: "two" is not of type integer`}, err)
+	})
+
+	t.Run("nested enums", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "object",
+			"properties": {
+				"phase": {"type": "string", "enum": ["Pending", "Running"]},
+				"replicas": {"type": "integer", "enum": [1, 2.5]},
+				"tags": {"type": "array", "items": {"type": "string", "enum": ["a", true]}},
+				"nullable": {"type": "string", "nullable": true, "enum": ["a", null]}
+			},
+			"definitions": {
+				"Flag": {"type": "boolean", "enum": [true, "false"]}
+			},
+			"allOf": [{"type": ["number", "string"], "enum": [1, "one", null]}]
+		}`), &schema))
+
+		err := schema.ValidateEnum()
+		require.Error(t, err)
+		assert.Equal(t, EnumErrors{
+			`definitions.Flag.enum[1]: "false" is not of type boolean`,
+			`properties.replicas.enum[1]: 2.5 is not of type integer`,
+			`properties.tags.items.enum[1]: true is not of type string`,
+			`allOf[0].enum[2]: <nil> is not of type number or string`,
+		}, err)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldInfo describes a leaf field of a schema, see FlattenFields.
+type FieldInfo struct {
+	// Path is the dotted path of the field, e.g. "spec.containers[].name". Array items are
+	// denoted by "[]" and map values, i.e. additionalProperties, by "{}".
+	Path string
+	// Type is the type of the field, e.g. "string", or the types joined by "," if several.
+	Type string
+	// Description of the field, or of the schema it references if it has none.
+	Description string
+	// Required is true if the field is required by its parent object. The items of arrays and
+	// the values of maps are required if the array or the map is.
+	Required bool
+}
+
+// FlattenFields lists the leaf fields of the schema, i.e. the fields which are neither objects
+// with properties, arrays nor maps, in the order of their paths. The "#/definitions/" references
+// are resolved against the definitions of root. A field whose schema is already being flattened
+// higher up its path, i.e. a recursive field, is listed as a leaf of the type of that schema.
+func (s *Schema) FlattenFields(root *Swagger) ([]FieldInfo, error) {
+	f := fieldFlattener{root: root, visiting: map[string]bool{}}
+	if err := f.flatten(s, "", "", false); err != nil {
+		return nil, err
+	}
+	return f.fields, nil
+}
+
+type fieldFlattener struct {
+	root *Swagger
+	// visiting holds the references being flattened, to bound cycles.
+	visiting map[string]bool
+	fields   []FieldInfo
+}
+
+func (f *fieldFlattener) flatten(s *Schema, path, description string, required bool) error {
+	ref := s.Ref.String()
+	if ref != "" {
+		if f.visiting[ref] {
+			def, _ := f.resolve(ref)
+			f.leaf(def, path, description, required)
+			return nil
+		}
+		def, err := f.resolve(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		f.visiting[ref] = true
+		defer delete(f.visiting, ref)
+		s = def
+	}
+	if description == "" {
+		description = s.Description
+	}
+
+	switch {
+	case len(s.Properties) > 0:
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop := s.Properties[name]
+			if err := f.flatten(&prop, joinFieldPath(path, name), prop.Description, containsString(s.Required, name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case s.Items != nil && s.Items.Schema != nil:
+		return f.flatten(s.Items.Schema, path+"[]", "", required)
+	case s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return f.flatten(s.AdditionalProperties.Schema, path+"{}", "", required)
+	}
+	f.leaf(s, path, description, required)
+	return nil
+}
+
+func (f *fieldFlattener) leaf(s *Schema, path, description string, required bool) {
+	if description == "" {
+		description = s.Description
+	}
+	f.fields = append(f.fields, FieldInfo{
+		Path:        path,
+		Type:        strings.Join(s.Type, ","),
+		Description: description,
+		Required:    required,
+	})
+}
+
+func (f *fieldFlattener) resolve(ref string) (*Schema, error) {
+	if !strings.HasPrefix(ref, definitionsRefPrefix) {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+	if f.root == nil {
+		return nil, fmt.Errorf("cannot resolve reference %q without a root spec", ref)
+	}
+	def, ok := f.root.Definitions[ref[len(definitionsRefPrefix):]]
+	if !ok {
+		return nil, fmt.Errorf("definition of reference %q not found", ref)
+	}
+	return &def, nil
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
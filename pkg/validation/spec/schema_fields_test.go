@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaFlattenFields(t *testing.T) {
+	var root Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"definitions": {
+			"Deployment": {
+				"type": "object",
+				"required": ["spec"],
+				"properties": {
+					"spec": {"$ref": "#/definitions/DeploymentSpec", "description": "Desired state."},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+				}
+			},
+			"DeploymentSpec": {
+				"type": "object",
+				"required": ["containers"],
+				"properties": {
+					"replicas": {"type": "integer", "description": "Number of pods."},
+					"containers": {"type": "array", "items": {"$ref": "#/definitions/Container"}}
+				}
+			},
+			"Container": {
+				"type": "object",
+				"description": "A container.",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"args": {"type": "array", "items": {"type": "string"}}
+				}
+			},
+			"Node": {
+				"type": "object",
+				"description": "A tree node.",
+				"properties": {
+					"value": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}
+				}
+			}
+		}
+	}`), &root))
+
+	tests := []struct {
+		name     string
+		expected []FieldInfo
+	}{
+		{
+			name: "Deployment",
+			expected: []FieldInfo{
+				{Path: "labels{}", Type: "string"},
+				{Path: "spec.containers[].args[]", Type: "string"},
+				{Path: "spec.containers[].name", Type: "string", Required: true},
+				{Path: "spec.replicas", Type: "integer", Description: "Number of pods."},
+			},
+		},
+		{
+			name: "Container",
+			expected: []FieldInfo{
+				{Path: "args[]", Type: "string"},
+				{Path: "name", Type: "string", Required: true},
+			},
+		},
+		{
+			name: "Node",
+			expected: []FieldInfo{
+				{Path: "children[]", Type: "object", Description: "A tree node."},
+				{Path: "value", Type: "string"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := RefSchema("#/definitions/" + tt.name).FlattenFields(&root)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+
+	_, err := RefSchema("#/definitions/Missing").FlattenFields(&root)
+	assert.Error(t, err)
+}
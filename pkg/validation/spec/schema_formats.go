@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import "math"
+
+// InferFormats sets the format of the integer schemas without format, including the nested ones, whose
+// minimum and maximum are both set: int32 if both bounds fit in an int32, int64 otherwise. Schemas with
+// a format or with an unbounded range are left unchanged.
+func (s *Schema) InferFormats() {
+	s.walk(func(schema *Schema) {
+		if schema.Format != "" || !schema.Type.Contains("integer") || schema.Minimum == nil || schema.Maximum == nil {
+			return
+		}
+		if *schema.Minimum >= math.MinInt32 && *schema.Maximum <= math.MaxInt32 {
+			schema.Format = "int32"
+		} else {
+			schema.Format = "int64"
+		}
+	})
+}
+
+// walk calls fn on the schema and on all its nested schemas. The schemas of maps are updated with the
+// changes made by fn.
+func (s *Schema) walk(fn func(*Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+
+	for _, schemas := range []map[string]Schema{s.Definitions, s.Properties, s.PatternProperties} {
+		for k, sch := range schemas {
+			sch.walk(fn)
+			schemas[k] = sch
+		}
+	}
+	for _, schemas := range [][]Schema{s.AllOf, s.AnyOf, s.OneOf} {
+		for i := range schemas {
+			schemas[i].walk(fn)
+		}
+	}
+	s.Not.walk(fn)
+	if s.AdditionalProperties != nil {
+		s.AdditionalProperties.Schema.walk(fn)
+	}
+	if s.AdditionalItems != nil {
+		s.AdditionalItems.Schema.walk(fn)
+	}
+	if s.Items != nil {
+		s.Items.Schema.walk(fn)
+		for i := range s.Items.Schemas {
+			s.Items.Schemas[i].walk(fn)
+		}
+	}
+	for _, dep := range s.Dependencies {
+		dep.Schema.walk(fn)
+	}
+}
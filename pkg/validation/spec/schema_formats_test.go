@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaInferFormats(t *testing.T) {
+	zero, one := 0.0, 1.0
+	integer := func() *Schema {
+		return &Schema{SchemaProps: SchemaProps{Type: []string{"integer"}}}
+	}
+	schema := &Schema{
+		SchemaProps: SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]Schema{
+				"port":      *integer().WithMinimum(1, false).WithMaximum(65535, false),
+				"size":      *integer().WithMinimum(0, false).WithMaximum(1<<40, false),
+				"count":     *integer(),
+				"positive":  *integer().WithMinimum(0, false),
+				"formatted": *Int64Property().WithMinimum(0, false).WithMaximum(10, false),
+				"ratio":     {SchemaProps: SchemaProps{Type: []string{"number"}, Minimum: &zero, Maximum: &one}},
+			},
+			Items: &SchemaOrArray{Schema: integer().WithMinimum(-10, true).WithMaximum(10, true)},
+		},
+	}
+
+	schema.InferFormats()
+
+	assert.Equal(t, "int32", schema.Properties["port"].Format)
+	assert.Equal(t, "int64", schema.Properties["size"].Format)
+	assert.Equal(t, "", schema.Properties["count"].Format, "unbounded")
+	assert.Equal(t, "", schema.Properties["positive"].Format, "no maximum")
+	assert.Equal(t, "int64", schema.Properties["formatted"].Format, "existing formats are kept")
+	assert.Equal(t, "", schema.Properties["ratio"].Format, "not an integer")
+	assert.Equal(t, "int32", schema.Items.Schema.Format)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Profile is a set of rules restricting the types, formats and keywords of schemas, see ValidateProfile.
+type Profile string
+
+const (
+	// ProfileSwagger2 allows the schemas of Swagger 2.0: a single type, and no nullable, anyOf, oneOf nor not.
+	ProfileSwagger2 Profile = "swagger2"
+	// ProfileOpenAPI3 allows the schemas of OpenAPI 3.0: a single type.
+	ProfileOpenAPI3 Profile = "openapi3"
+	// ProfileKubernetesStructural allows the structural schemas of Kubernetes custom resources: every schema
+	// outside of allOf, anyOf, oneOf and not has a single type, unless it is an int-or-string or preserves
+	// unknown fields, and $ref, definitions, dependencies, patternProperties, additionalItems and tuple
+	// items are forbidden, as are properties alongside additionalProperties.
+	ProfileKubernetesStructural Profile = "kubernetes-structural"
+)
+
+// ProfileErrors lists the violations of a profile by a schema.
+type ProfileErrors []string
+
+// Error implements the error interface
+func (e ProfileErrors) Error() string {
+	return fmt.Sprintf("schema violates the rules of the profile: %s", strings.Join(e, "; "))
+}
+
+// knownTypes are the types allowed by all the profiles.
+var knownTypes = map[string]bool{"string": true, "number": true, "integer": true, "boolean": true, "array": true, "object": true}
+
+// formatTypes maps the formats defined for non-string types to the types they apply to.
+var formatTypes = map[string][]string{
+	"int32":  {"integer", "number"},
+	"int64":  {"integer", "number"},
+	"float":  {"number"},
+	"double": {"number"},
+}
+
+// stringFormats are formats which only apply to strings.
+var stringFormats = map[string]bool{"byte": true, "binary": true, "date": true, "date-time": true, "password": true}
+
+// ValidateProfile checks the types, the formats and the keywords of the schema and of all its nested
+// schemas against the rules of the profile. In all profiles, formats must apply to the type of their
+// schema, e.g. "int32" does not apply to strings. It returns a ProfileErrors listing all the violations,
+// or nil.
+func (s *Schema) ValidateProfile(profile Profile) error {
+	switch profile {
+	case ProfileSwagger2, ProfileOpenAPI3, ProfileKubernetesStructural:
+	default:
+		return fmt.Errorf("unknown profile %q", profile)
+	}
+	c := profileChecker{profile: profile}
+	v := SchemaVisitor{
+		SchemaAt: func(schema *Schema, loc *SchemaLocation) bool {
+			c.check(schema, loc.Path, inJunctor(loc))
+			return true
+		},
+	}
+	v.Walk(s)
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+type profileChecker struct {
+	profile Profile
+	errs    ProfileErrors
+}
+
+func (c *profileChecker) addf(path, format string, args ...interface{}) {
+	c.errs = append(c.errs, path+fmt.Sprintf(format, args...))
+}
+
+// check checks the schema found at path, but not its sub-schemas. inJunctor is true for the schemas
+// under allOf, anyOf, oneOf or not.
+func (c *profileChecker) check(s *Schema, path string, inJunctor bool) {
+	for _, t := range s.Type {
+		if !knownTypes[t] {
+			c.addf(path, "type: unsupported type %q", t)
+		}
+	}
+	if len(s.Type) > 1 {
+		c.addf(path, "type: multiple types %s are not allowed", strings.Join(s.Type, ", "))
+	}
+	if s.Format != "" && len(s.Type) == 1 {
+		if types, ok := formatTypes[s.Format]; ok && !containsType(types, s.Type[0]) {
+			c.addf(path, "format: %s does not apply to type %s", s.Format, s.Type[0])
+		} else if stringFormats[s.Format] && s.Type[0] != "string" {
+			c.addf(path, "format: %s does not apply to type %s", s.Format, s.Type[0])
+		}
+	}
+
+	switch c.profile {
+	case ProfileSwagger2:
+		if s.Nullable {
+			c.addf(path, "nullable: not allowed")
+		}
+		c.forbid(path, []keywordUse{
+			{"anyOf", len(s.AnyOf) > 0},
+			{"oneOf", len(s.OneOf) > 0},
+			{"not", s.Not != nil},
+		})
+	case ProfileKubernetesStructural:
+		c.checkStructural(s, path, inJunctor)
+	}
+}
+
+// inJunctor returns true if loc is under allOf, anyOf, oneOf or not.
+func inJunctor(loc *SchemaLocation) bool {
+	for ; loc != nil; loc = loc.Parent {
+		switch loc.Keyword {
+		case "allOf", "anyOf", "oneOf", "not":
+			return true
+		}
+	}
+	return false
+}
+
+func (c *profileChecker) checkStructural(s *Schema, path string, inJunctor bool) {
+	if s.Ref.String() != "" {
+		c.addf(path, "$ref: not allowed")
+	}
+	if len(s.Type) == 0 && !inJunctor && !hasTrueExtension(s, "x-kubernetes-int-or-string") && !hasTrueExtension(s, "x-kubernetes-preserve-unknown-fields") {
+		c.addf(path, "type: must not be empty")
+	}
+	c.forbid(path, []keywordUse{
+		{"definitions", len(s.Definitions) > 0},
+		{"dependencies", len(s.Dependencies) > 0},
+		{"patternProperties", len(s.PatternProperties) > 0},
+		{"additionalItems", s.AdditionalItems != nil},
+		{"items", s.Items != nil && len(s.Items.Schemas) > 0},
+	})
+	if len(s.Properties) > 0 && s.AdditionalProperties != nil && (s.AdditionalProperties.Schema != nil || s.AdditionalProperties.Allows) {
+		c.addf(path, "additionalProperties: not allowed alongside properties")
+	}
+}
+
+// keywordUse records whether a keyword is used by a schema.
+type keywordUse struct {
+	keyword string
+	used    bool
+}
+
+// forbid reports the keywords used by the schema at path.
+func (c *profileChecker) forbid(path string, uses []keywordUse) {
+	for _, u := range uses {
+		if u.used {
+			c.addf(path, "%s: not allowed", u.keyword)
+		}
+	}
+}
+
+func containsType(types []string, t string) bool {
+	for _, e := range types {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTrueExtension(s *Schema, name string) bool {
+	v, ok := s.Extensions.GetBool(name)
+	return ok && v
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProfile(t *testing.T) {
+	t.Run("compliant structural schema", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "object",
+			"properties": {
+				"replicas": {"type": "integer", "format": "int32"},
+				"port": {"x-kubernetes-int-or-string": true, "anyOf": [{"type": "integer"}, {"type": "string"}]},
+				"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+				"created": {"type": "string", "format": "date-time", "nullable": true}
+			}
+		}`), &schema))
+		assert.NoError(t, schema.ValidateProfile(ProfileKubernetesStructural))
+		assert.NoError(t, schema.ValidateProfile(ProfileOpenAPI3))
+	})
+
+	t.Run("structural violations", func(t *testing.T) {
+		var schema Schema
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"$ref": "#/definitions/Foo",
+			"description": "a foo",
+			"properties": {
+				"name": {"type": ["string", "null"]},
+				"size": {"type": "string", "format": "int64"},
+				"tags": {"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": true}
+			}
+		}`), &schema))
+		err := schema.ValidateProfile(ProfileKubernetesStructural)
+		require.Error(t, err)
+		assert.Equal(t, ProfileErrors{
+			"$ref: not allowed",
+			"type: must not be empty",
+			`properties.name.type: unsupported type "null"`,
+			"properties.name.type: multiple types string, null are not allowed",
+			"properties.size.format: int64 does not apply to type string",
+			"properties.tags.additionalProperties: not allowed alongside properties",
+		}, err)
+	})
+
+	t.Run("swagger 2.0 keywords", func(t *testing.T) {
+		schema := (&Schema{}).Typed("integer", "date-time").AsNullable()
+		schema.Not = StringProperty()
+		assert.Equal(t, ProfileErrors{
+			"format: date-time does not apply to type integer",
+			"nullable: not allowed",
+			"not: not allowed",
+		}, schema.ValidateProfile(ProfileSwagger2))
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		assert.EqualError(t, StringProperty().ValidateProfile("jsonschema"), `unknown profile "jsonschema"`)
+	})
+}
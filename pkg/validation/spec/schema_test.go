@@ -195,9 +195,63 @@ func TestSchema(t *testing.T) {
 
 }
 
+func TestSchemaPatternProperties(t *testing.T) {
+	const patternJSON = `{"type":"object","patternProperties":{"^x-":{"type":"string"},"^[a-z]+$":{"type":"integer","minimum":0}},"additionalProperties":false}`
+
+	var actual Schema
+	if assert.NoError(t, json.Unmarshal([]byte(patternJSON), &actual)) {
+		minimum := 0.0
+		assert.Equal(t, map[string]Schema{
+			"^x-":      *StringProperty(),
+			"^[a-z]+$": {SchemaProps: SchemaProps{Type: []string{"integer"}, Minimum: &minimum}},
+		}, actual.PatternProperties)
+	}
+
+	b, err := json.Marshal(actual)
+	if assert.NoError(t, err) {
+		// keys are sorted, so that the output is stable
+		assert.Equal(t, `{"type":"object","additionalProperties":false,"patternProperties":{"^[a-z]+$":{"type":"integer","minimum":0},"^x-":{"type":"string"}}}`, string(b))
+	}
+}
+
 func BenchmarkSchemaUnmarshal(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		sch := &Schema{}
 		_ = sch.UnmarshalJSON([]byte(schemaJSON))
 	}
 }
+
+func TestSchemaMergeExtensions(t *testing.T) {
+	s := new(Schema)
+	s.AddExtension("x-kubernetes-list-type", "atomic")
+	s.MergeExtensions(Extensions{"X-Kubernetes-List-Type": "set", "x-kubernetes-map-type": "granular"}, false)
+	assert.Equal(t, Extensions{"x-kubernetes-list-type": "atomic", "x-kubernetes-map-type": "granular"}, s.Extensions)
+
+	s.MergeExtensions(Extensions{"x-kubernetes-list-type": "set"}, true)
+	assert.Equal(t, "set", s.Extensions["x-kubernetes-list-type"])
+
+	gvk := func(group, version, kind string) map[string]interface{} {
+		return map[string]interface{}{"group": group, "version": version, "kind": kind}
+	}
+	s = new(Schema)
+	s.AddExtension("x-kubernetes-group-version-kind", []interface{}{gvk("", "v1", "Pod")})
+	s.AddExtension("x-custom", map[string]interface{}{
+		"a":      "1",
+		"nested": map[string]interface{}{"b": "2"},
+	})
+	other := Extensions{
+		"x-kubernetes-group-version-kind": []interface{}{gvk("", "v1", "Pod"), gvk("apps", "v1", "Deployment")},
+		"x-custom": map[string]interface{}{
+			"a":      "conflict",
+			"nested": map[string]interface{}{"c": "3"},
+		},
+	}
+	s.MergeExtensions(other, false)
+	assert.Equal(t, []interface{}{gvk("", "v1", "Pod"), gvk("apps", "v1", "Deployment")}, s.Extensions["x-kubernetes-group-version-kind"])
+	assert.Equal(t, map[string]interface{}{
+		"a":      "1",
+		"nested": map[string]interface{}{"b": "2", "c": "3"},
+	}, s.Extensions["x-custom"])
+	// the merged values are not shared with the given extensions
+	assert.Equal(t, map[string]interface{}{"c": "3"}, other["x-custom"].(map[string]interface{})["nested"])
+}
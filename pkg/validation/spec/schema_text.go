@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+// ellipsis ends the texts truncated by CapText.
+const ellipsis = "…"
+
+// CapText truncates the title and the description of the schema and of all its nested schemas which
+// are longer than maxLen runes. Truncated texts end with an ellipsis and are maxLen runes long.
+// A maxLen lower than 1 leaves the schema unchanged.
+func (s *Schema) CapText(maxLen int) {
+	if maxLen < 1 {
+		return
+	}
+	s.walk(func(schema *Schema) {
+		schema.Title = capText(schema.Title, maxLen)
+		schema.Description = capText(schema.Description, maxLen)
+	})
+}
+
+// capText returns text truncated to maxLen runes, the last one being an ellipsis, if it is longer.
+func capText(text string, maxLen int) string {
+	if len(text) <= maxLen {
+		// no more runes than bytes
+		return text
+	}
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	return string(runes[:maxLen-1]) + ellipsis
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaCapText(t *testing.T) {
+	long := "Größenänderung der Ressource, ausgedrückt in Bytes"
+	schema := &Schema{
+		SchemaProps: SchemaProps{
+			Title:       "Short",
+			Description: long,
+			Properties: map[string]Schema{
+				"size": {SchemaProps: SchemaProps{Description: long}},
+			},
+			Items: &SchemaOrArray{Schema: &Schema{SchemaProps: SchemaProps{Title: long}}},
+		},
+	}
+
+	schema.CapText(10)
+
+	assert.Equal(t, "Short", schema.Title)
+	assert.Equal(t, "Größenänd…", schema.Description)
+	assert.True(t, utf8.ValidString(schema.Description))
+	assert.Equal(t, 10, utf8.RuneCountInString(schema.Description))
+	assert.Equal(t, "Größenänd…", schema.Properties["size"].Description)
+	assert.Equal(t, "Größenänd…", schema.Items.Schema.Title)
+
+	// texts as long as the limit are untouched, even if they have more bytes than runes
+	schema = &Schema{SchemaProps: SchemaProps{Description: "Größe"}}
+	schema.CapText(5)
+	assert.Equal(t, "Größe", schema.Description)
+}
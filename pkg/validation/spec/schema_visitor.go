@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+// SchemaVisitor walks schema trees without modifying them, e.g. to collect metrics. The sub-schemas
+// of definitions, properties, patternProperties, additionalProperties, additionalItems, items,
+// allOf, anyOf, oneOf, not and dependencies are visited in a deterministic order.
+type SchemaVisitor struct {
+	// Schema is called for every schema, with its depth in the tree, the walked schema being at depth 0.
+	// Its sub-schemas are skipped if it returns false. It may be nil.
+	Schema func(schema *Schema, depth int) bool
+
+	// SchemaAt is like Schema, with the location of the schema in the tree instead of its depth. It is
+	// called after Schema, and the sub-schemas are skipped if either returns false. It may be nil.
+	SchemaAt func(schema *Schema, loc *SchemaLocation) bool
+
+	// Ref is called for every $ref, with the depth of the schema holding it. It may be nil.
+	Ref func(ref *Ref, depth int)
+
+	// Definitions resolves the "#/definitions/" references, e.g. the definitions of a Swagger.
+	// The definitions referenced are walked, one level deeper than the schema holding the
+	// reference, the first time they are referenced only, so that cyclic references terminate.
+	// References are not followed if nil.
+	Definitions Definitions
+
+	visited map[string]bool
+}
+
+// SchemaLocation locates a schema visited by a SchemaVisitor.
+type SchemaLocation struct {
+	// Parent is the location of the parent schema, nil for the walked schema.
+	Parent *SchemaLocation
+	// Keyword is the keyword of the parent schema holding the schema, e.g. "items", or "$ref" for
+	// the definitions followed through a reference. It is empty for the walked schema.
+	Keyword string
+	// Path is the path of the schema from the walked schema, e.g. "properties.foo.items." or
+	// "allOf[0].$ref.". It is empty for the walked schema.
+	Path string
+	// Depth is the depth of the schema in the tree, the walked schema being at depth 0.
+	Depth int
+}
+
+// Walk visits schema and its sub-schemas. The references already followed by previous walks
+// of the same visitor are not followed again.
+func (v *SchemaVisitor) Walk(schema *Schema) {
+	v.walk(schema, &SchemaLocation{})
+}
+
+func (v *SchemaVisitor) walk(s *Schema, loc *SchemaLocation) {
+	if s == nil {
+		return
+	}
+	if v.Schema != nil && !v.Schema(s, loc.Depth) {
+		return
+	}
+	if v.SchemaAt != nil && !v.SchemaAt(s, loc) {
+		return
+	}
+	if s.Ref.String() != "" {
+		v.walkRef(&s.Ref, loc)
+	}
+
+	forEachSubSchema(s, func(sub *Schema, keyword, path string) bool {
+		v.walk(sub, &SchemaLocation{Parent: loc, Keyword: keyword, Path: loc.Path + path, Depth: loc.Depth + 1})
+		return false
+	})
+}
+
+func (v *SchemaVisitor) walkRef(ref *Ref, loc *SchemaLocation) {
+	if v.Ref != nil {
+		v.Ref(ref, loc.Depth)
+	}
+	if v.Definitions == nil {
+		return
+	}
+	section, name, ok := localRefTarget(ref)
+	if !ok || section != "definitions" {
+		return
+	}
+	def, ok := v.Definitions[name]
+	if !ok || v.visited[name] {
+		return
+	}
+	if v.visited == nil {
+		v.visited = map[string]bool{}
+	}
+	v.visited[name] = true
+	v.walk(&def, &SchemaLocation{Parent: loc, Keyword: "$ref", Path: loc.Path + "$ref.", Depth: loc.Depth + 1})
+}
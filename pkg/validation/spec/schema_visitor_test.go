@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVisitor(t *testing.T) {
+	var definitions Definitions
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Pod": {
+			"type": "object",
+			"properties": {
+				"metadata": {"$ref": "#/definitions/ObjectMeta"},
+				"containers": {"type": "array", "items": {"$ref": "#/definitions/Container"}},
+				"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+			}
+		},
+		"Container": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"port": {"anyOf": [{"type": "integer"}, {"type": "string"}]}
+			}
+		},
+		"ObjectMeta": {
+			"type": "object",
+			"properties": {
+				"owner": {"$ref": "#/definitions/ObjectMeta"}
+			}
+		}
+	}`), &definitions))
+
+	var schemas, refs, maxDepth int
+	v := SchemaVisitor{
+		Schema: func(schema *Schema, depth int) bool {
+			schemas++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+			return true
+		},
+		Ref: func(ref *Ref, depth int) {
+			refs++
+		},
+		Definitions: definitions,
+	}
+	pod := definitions["Pod"]
+	v.Walk(&pod)
+
+	// Pod, metadata, ObjectMeta, owner, containers, items, Container, name, port, integer, string, labels, additionalProperties
+	assert.Equal(t, 13, schemas)
+	// metadata, owner (cyclic, not followed again), items
+	assert.Equal(t, 3, refs)
+	// Pod > containers > items > Container > port > integer
+	assert.Equal(t, 5, maxDepth)
+
+	// without definitions, the references are not followed
+	schemas, refs = 0, 0
+	v = SchemaVisitor{
+		Schema: func(schema *Schema, depth int) bool {
+			schemas++
+			return schema.Ref.String() == ""
+		},
+	}
+	v.Walk(&pod)
+	assert.Equal(t, 6, schemas)
+	assert.Equal(t, 0, refs)
+
+	// the locations give the paths of the schemas, and their keyword
+	var paths, keywords []string
+	v = SchemaVisitor{
+		SchemaAt: func(schema *Schema, loc *SchemaLocation) bool {
+			paths = append(paths, loc.Path)
+			keywords = append(keywords, loc.Keyword)
+			return true
+		},
+		Definitions: definitions,
+	}
+	container := definitions["Container"]
+	v.Walk(&container)
+	assert.Equal(t, []string{"", "properties.name.", "properties.port.", "properties.port.anyOf[0].", "properties.port.anyOf[1]."}, paths)
+	assert.Equal(t, []string{"", "properties", "properties", "anyOf", "anyOf"}, keywords)
+
+	paths = nil
+	v.Walk(&pod)
+	assert.Contains(t, paths, "properties.metadata.$ref.properties.owner.")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"sort"
+)
+
+// CoverageReport returns the names of the definitions and of the global parameters which are
+// not reachable from any path, following references transitively. The spec is not modified.
+func (s *Swagger) CoverageReport() (unusedDefs []string, unusedParams []string) {
+	reached := map[string]map[string]bool{
+		"definitions": {},
+		"parameters":  {},
+		"responses":   {},
+	}
+	var w refWalker
+	w = func(ref *Ref) bool {
+		section, name, ok := localRefTarget(ref)
+		if !ok || reached[section][name] {
+			return false
+		}
+		reached[section][name] = true
+		switch section {
+		case "definitions":
+			if def, ok := s.Definitions[name]; ok {
+				w.walkSchema(&def)
+			}
+		case "parameters":
+			if param, ok := s.Parameters[name]; ok {
+				w.walkParameter(&param)
+			}
+		case "responses":
+			if resp, ok := s.Responses[name]; ok {
+				w.walkResponse(&resp)
+			}
+		}
+		return false
+	}
+	if s.Paths != nil {
+		for _, pathItem := range s.Paths.Paths {
+			w.walkPathItem(&pathItem)
+		}
+	}
+
+	for name := range s.Definitions {
+		if !reached["definitions"][name] {
+			unusedDefs = append(unusedDefs, name)
+		}
+	}
+	for name := range s.Parameters {
+		if !reached["parameters"][name] {
+			unusedParams = append(unusedParams, name)
+		}
+	}
+	sort.Strings(unusedDefs)
+	sort.Strings(unusedParams)
+	return unusedDefs, unusedParams
+}
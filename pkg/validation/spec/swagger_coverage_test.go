@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerCoverageReport(t *testing.T) {
+	var swagger Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"paths": {
+			"/pets": {
+				"parameters": [{"$ref": "#/parameters/limit"}],
+				"get": {
+					"parameters": [{"$ref": "#/parameters/filter"}],
+					"responses": {
+						"200": {"description": "ok", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}},
+						"default": {"$ref": "#/responses/Error"}
+					}
+				}
+			}
+		},
+		"definitions": {
+			"Pet": {"properties": {"owner": {"$ref": "#/definitions/Person"}}},
+			"Person": {"properties": {"friends": {"type": "array", "items": {"$ref": "#/definitions/Person"}}, "address": {"$ref": "#/definitions/io.k8s~1Address"}}},
+			"io.k8s/Address": {"type": "object"},
+			"Error": {"type": "object"},
+			"Filter": {"type": "string"},
+			"Orphan": {"properties": {"other": {"$ref": "#/definitions/OrphanChild"}}},
+			"OrphanChild": {"type": "object"}
+		},
+		"parameters": {
+			"limit": {"name": "limit", "in": "query", "type": "integer"},
+			"filter": {"name": "filter", "in": "body", "schema": {"$ref": "#/definitions/Filter"}},
+			"unusedParam": {"name": "unused", "in": "body", "schema": {"$ref": "#/definitions/Orphan"}}
+		},
+		"responses": {
+			"Error": {"description": "error", "schema": {"$ref": "#/definitions/Error"}}
+		}
+	}`), &swagger))
+	before, err := json.Marshal(swagger)
+	require.NoError(t, err)
+
+	unusedDefs, unusedParams := swagger.CoverageReport()
+	assert.Equal(t, []string{"Orphan", "OrphanChild"}, unusedDefs)
+	assert.Equal(t, []string{"unusedParam"}, unusedParams)
+
+	after, err := json.Marshal(swagger)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+
+	unusedDefs, unusedParams = (&Swagger{}).CoverageReport()
+	assert.Empty(t, unusedDefs)
+	assert.Empty(t, unusedParams)
+}
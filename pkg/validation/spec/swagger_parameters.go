@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolvedParameters returns the parameters of the operation of the given method (e.g. "GET") and path,
+// including the parameters shared by all the operations of the path. References to the global parameters
+// of the spec are resolved. A parameter is identified by its name and location: operation parameters
+// override the path parameters with the same name and location. The path parameters come first.
+func (s *Swagger) ResolvedParameters(method, path string) ([]Parameter, error) {
+	if s.Paths == nil {
+		return nil, fmt.Errorf("path %q not found", path)
+	}
+	pathItem, ok := s.Paths.Paths[path]
+	if !ok {
+		return nil, fmt.Errorf("path %q not found", path)
+	}
+	var op *Operation
+	switch strings.ToUpper(method) {
+	case "GET":
+		op = pathItem.Get
+	case "PUT":
+		op = pathItem.Put
+	case "POST":
+		op = pathItem.Post
+	case "DELETE":
+		op = pathItem.Delete
+	case "OPTIONS":
+		op = pathItem.Options
+	case "HEAD":
+		op = pathItem.Head
+	case "PATCH":
+		op = pathItem.Patch
+	}
+	if op == nil {
+		return nil, fmt.Errorf("operation %s not found for path %q", strings.ToUpper(method), path)
+	}
+
+	pathParams, err := s.resolveParameters(pathItem.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	opParams, err := s.resolveParameters(op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ name, in string }
+	overridden := make(map[key]bool, len(opParams))
+	for _, p := range opParams {
+		overridden[key{p.Name, p.In}] = true
+	}
+	ret := make([]Parameter, 0, len(pathParams)+len(opParams))
+	for _, p := range pathParams {
+		if !overridden[key{p.Name, p.In}] {
+			ret = append(ret, p)
+		}
+	}
+	return append(ret, opParams...), nil
+}
+
+// resolveParameters returns a copy of params in which the references to global parameters are resolved.
+func (s *Swagger) resolveParameters(params []Parameter) ([]Parameter, error) {
+	ret := make([]Parameter, 0, len(params))
+	for _, p := range params {
+		ref := p.Ref.String()
+		if ref == "" {
+			ret = append(ret, p)
+			continue
+		}
+		if !strings.HasPrefix(ref, parametersRefPrefix) {
+			return nil, fmt.Errorf("unsupported parameter reference %q", ref)
+		}
+		resolved, ok := s.Parameters[ref[len(parametersRefPrefix):]]
+		if !ok {
+			return nil, fmt.Errorf("parameter reference %q not found", ref)
+		}
+		ret = append(ret, resolved)
+	}
+	return ret, nil
+}
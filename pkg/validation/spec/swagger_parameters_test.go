@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerResolvedParameters(t *testing.T) {
+	var swagger Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"paths": {
+			"/api/v1/namespaces/{namespace}/pods/{name}": {
+				"parameters": [
+					{"$ref": "#/parameters/name-path"},
+					{"$ref": "#/parameters/namespace-vgWSWtn3"},
+					{"$ref": "#/parameters/pretty-nN7o5FEq"}
+				],
+				"get": {
+					"operationId": "readNamespacedPod",
+					"responses": {"200": {"description": "OK"}}
+				},
+				"patch": {
+					"operationId": "patchNamespacedPod",
+					"parameters": [
+						{"name": "body", "in": "body", "required": true, "schema": {"type": "object"}},
+						{"name": "pretty", "in": "query", "type": "string", "description": "overridden"},
+						{"$ref": "#/parameters/dryRun"}
+					],
+					"responses": {"200": {"description": "OK"}}
+				},
+				"delete": {
+					"operationId": "deleteNamespacedPod",
+					"parameters": [{"$ref": "#/parameters/missing"}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"parameters": {
+			"name-path": {"name": "name", "in": "path", "required": true, "type": "string", "uniqueItems": true},
+			"namespace-vgWSWtn3": {"name": "namespace", "in": "path", "required": true, "type": "string", "uniqueItems": true},
+			"pretty-nN7o5FEq": {"name": "pretty", "in": "query", "type": "string", "uniqueItems": true, "description": "If 'true', then the output is pretty printed."},
+			"dryRun": {"name": "dryRun", "in": "query", "type": "string", "uniqueItems": true}
+		}
+	}`), &swagger))
+	path := "/api/v1/namespaces/{namespace}/pods/{name}"
+	names := func(params []Parameter) []string {
+		var ret []string
+		for _, p := range params {
+			ret = append(ret, p.In+":"+p.Name)
+		}
+		return ret
+	}
+
+	params, err := swagger.ResolvedParameters("get", path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"path:name", "path:namespace", "query:pretty"}, names(params))
+	assert.Equal(t, "If 'true', then the output is pretty printed.", params[2].Description)
+	for _, p := range params {
+		assert.Empty(t, p.Ref.String())
+	}
+
+	params, err = swagger.ResolvedParameters("PATCH", path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"path:name", "path:namespace", "body:body", "query:pretty", "query:dryRun"}, names(params))
+	assert.Equal(t, "overridden", params[3].Description)
+
+	_, err = swagger.ResolvedParameters("DELETE", path)
+	assert.EqualError(t, err, `parameter reference "#/parameters/missing" not found`)
+	_, err = swagger.ResolvedParameters("PUT", path)
+	assert.Error(t, err)
+	_, err = swagger.ResolvedParameters("GET", "/unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// RepairRefs fixes the references to definitions which do not exist in the spec, e.g. after a
+// definition has been renamed. A dangling reference is repaired if exactly one definition matches its
+// name, first ignoring case, then also ignoring punctuation (e.g. "io.k8s.Foo_v1" matches "io.k8s.foo.v1").
+// It returns the references which could not be repaired, sorted.
+func (s *Swagger) RepairRefs() []string {
+	byFold := map[string][]string{}
+	byNormalized := map[string][]string{}
+	for name := range s.Definitions {
+		byFold[strings.ToLower(name)] = append(byFold[strings.ToLower(name)], name)
+		byNormalized[normalizeDefinitionName(name)] = append(byNormalized[normalizeDefinitionName(name)], name)
+	}
+
+	unrepairable := map[string]bool{}
+	refWalker(func(ref *Ref) bool {
+		section, name, ok := localRefTarget(ref)
+		if !ok || section != "definitions" {
+			return false
+		}
+		if _, ok := s.Definitions[name]; ok {
+			return false
+		}
+		var candidates []string
+		if candidates = byFold[strings.ToLower(name)]; len(candidates) != 1 {
+			candidates = byNormalized[normalizeDefinitionName(name)]
+		}
+		if len(candidates) != 1 {
+			unrepairable[ref.String()] = true
+			return false
+		}
+		*ref = definitionRef(candidates[0])
+		return true
+	}).walkSwagger(s)
+
+	ret := make([]string, 0, len(unrepairable))
+	for ref := range unrepairable {
+		ret = append(ret, ref)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// normalizeDefinitionName lower-cases name and drops all characters but letters and digits.
+func normalizeDefinitionName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
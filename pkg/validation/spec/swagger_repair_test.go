@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerRepairRefs(t *testing.T) {
+	var swagger Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"description": "ok", "schema": {"type": "array", "items": {"$ref": "#/definitions/pet"}}},
+						"404": {"description": "not found", "schema": {"$ref": "#/definitions/Missing"}}
+					}
+				}
+			}
+		},
+		"definitions": {
+			"Pet": {"properties": {"owner": {"$ref": "#/definitions/io.k8s.Person_v1"}, "toy": {"$ref": "#/definitions/toys~1ball~0v1"}}},
+			"Toys/Ball~v1": {"type": "object"},
+			"io.k8s.person.v1": {"type": "object"},
+			"Status": {"type": "object"},
+			"status": {"type": "object"}
+		},
+		"parameters": {
+			"status": {"name": "status", "in": "body", "schema": {"$ref": "#/definitions/STATUS"}}
+		}
+	}`), &swagger))
+
+	unrepairable := swagger.RepairRefs()
+	assert.Equal(t, []string{"#/definitions/Missing", "#/definitions/STATUS"}, unrepairable)
+
+	resp := swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "#/definitions/Pet", resp.Schema.Items.Schema.Ref.String())
+	owner := swagger.Definitions["Pet"].Properties["owner"]
+	assert.Equal(t, "#/definitions/io.k8s.person.v1", owner.Ref.String())
+	// names are unescaped and escaped as JSON pointer tokens
+	toy := swagger.Definitions["Pet"].Properties["toy"]
+	assert.Equal(t, "#/definitions/Toys~1Ball~0v1", toy.Ref.String())
+	resp = swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[404]
+	assert.Equal(t, "#/definitions/Missing", resp.Schema.Ref.String())
+	assert.Equal(t, "#/definitions/STATUS", swagger.Parameters["status"].Schema.Ref.String())
+
+	// all repairs are done, only the unrepairable refs are left
+	assert.Equal(t, unrepairable, swagger.RepairRefs())
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveRef returns the schema designated by a local reference of the spec: a definition (e.g.
+// "#/definitions/Foo"), the schema of a global parameter ("#/parameters/body") or of a global
+// response ("#/responses/NotFound"), or a schema nested in one of them (e.g.
+// "#/definitions/Foo/properties/bar" or "#/parameters/body/schema/items"). If the designated
+// schema is itself a reference, it is resolved in turn. External references, unresolvable
+// references and reference cycles are errors. The returned schema shares its maps and slices
+// with the spec.
+func (s *Swagger) ResolveRef(ref Ref) (*Schema, error) {
+	visited := map[string]bool{}
+	for {
+		refStr := ref.String()
+		if !ref.HasFragmentOnly {
+			return nil, fmt.Errorf("cannot resolve reference %q: only local references are supported", refStr)
+		}
+		if visited[refStr] {
+			return nil, fmt.Errorf("cannot resolve reference %q: circular reference", refStr)
+		}
+		visited[refStr] = true
+
+		schema, err := s.resolveLocalPointer(ref.GetPointer().DecodedTokens())
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve reference %q: %v", refStr, err)
+		}
+		if schema.Ref.String() == "" {
+			return schema, nil
+		}
+		ref = schema.Ref
+	}
+}
+
+// resolveLocalPointer returns the schema of the spec designated by the given decoded JSON pointer tokens.
+func (s *Swagger) resolveLocalPointer(tokens []string) (*Schema, error) {
+	if len(tokens) < 2 {
+		return nil, fmt.Errorf("pointer must designate a definition, a parameter or a response")
+	}
+	var schema *Schema
+	rest := tokens[2:]
+	switch tokens[0] {
+	case "definitions":
+		def, ok := s.Definitions[tokens[1]]
+		if !ok {
+			return nil, fmt.Errorf("definition %q not found", tokens[1])
+		}
+		schema = &def
+	case "parameters", "responses":
+		if tokens[0] == "parameters" {
+			param, ok := s.Parameters[tokens[1]]
+			if !ok {
+				return nil, fmt.Errorf("parameter %q not found", tokens[1])
+			}
+			schema = param.Schema
+		} else {
+			resp, ok := s.Responses[tokens[1]]
+			if !ok {
+				return nil, fmt.Errorf("response %q not found", tokens[1])
+			}
+			schema = resp.Schema
+		}
+		if len(rest) > 0 {
+			if rest[0] != "schema" {
+				return nil, fmt.Errorf("unsupported keyword %q in /%s", rest[0], strings.Join(tokens, "/"))
+			}
+			rest = rest[1:]
+		}
+		if schema == nil {
+			return nil, fmt.Errorf("%s %q has no schema", strings.TrimSuffix(tokens[0], "s"), tokens[1])
+		}
+	default:
+		return nil, fmt.Errorf("unsupported keyword %q in /%s", tokens[0], strings.Join(tokens, "/"))
+	}
+	return schema.ResolvePointer(rest)
+}
+
+// ResolvePointer returns the schema nested in s designated by the decoded tokens of a JSON pointer,
+// e.g. []string{"properties", "spec", "items"}. References are not followed.
+func (s *Schema) ResolvePointer(tokens []string) (*Schema, error) {
+	current := s
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		next := func() (string, error) {
+			if i+1 >= len(tokens) {
+				return "", fmt.Errorf("missing key after %q", token)
+			}
+			i++
+			return tokens[i], nil
+		}
+		nextIndex := func(len int) (int, error) {
+			key, err := next()
+			if err != nil {
+				return 0, err
+			}
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len {
+				return 0, fmt.Errorf("invalid index %q in %q", key, token)
+			}
+			return index, nil
+		}
+
+		switch token {
+		case "definitions", "properties", "patternProperties", "dependencies":
+			key, err := next()
+			if err != nil {
+				return nil, err
+			}
+			var sch Schema
+			var ok bool
+			switch token {
+			case "definitions":
+				sch, ok = current.Definitions[key]
+			case "properties":
+				sch, ok = current.Properties[key]
+			case "patternProperties":
+				sch, ok = current.PatternProperties[key]
+			case "dependencies":
+				var dep SchemaOrStringArray
+				dep, ok = current.Dependencies[key]
+				if ok && dep.Schema != nil {
+					sch = *dep.Schema
+				} else {
+					ok = false
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("%q not found in %q", key, token)
+			}
+			current = &sch
+		case "allOf", "anyOf", "oneOf":
+			schemas := current.AllOf
+			if token == "anyOf" {
+				schemas = current.AnyOf
+			} else if token == "oneOf" {
+				schemas = current.OneOf
+			}
+			index, err := nextIndex(len(schemas))
+			if err != nil {
+				return nil, err
+			}
+			current = &schemas[index]
+		case "items":
+			if current.Items == nil {
+				return nil, fmt.Errorf("no items")
+			}
+			if current.Items.Schema != nil {
+				current = current.Items.Schema
+				continue
+			}
+			index, err := nextIndex(len(current.Items.Schemas))
+			if err != nil {
+				return nil, err
+			}
+			current = &current.Items.Schemas[index]
+		case "not":
+			if current.Not == nil {
+				return nil, fmt.Errorf("no %q schema", token)
+			}
+			current = current.Not
+		case "additionalProperties", "additionalItems":
+			schemaOrBool := current.AdditionalProperties
+			if token == "additionalItems" {
+				schemaOrBool = current.AdditionalItems
+			}
+			if schemaOrBool == nil || schemaOrBool.Schema == nil {
+				return nil, fmt.Errorf("no %q schema", token)
+			}
+			current = schemaOrBool.Schema
+		default:
+			return nil, fmt.Errorf("unsupported keyword %q in /%s", token, strings.Join(tokens, "/"))
+		}
+	}
+	return current, nil
+}
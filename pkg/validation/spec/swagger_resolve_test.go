@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerResolveRef(t *testing.T) {
+	var swagger Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"definitions": {
+			"Pod": {
+				"type": "object",
+				"properties": {
+					"spec": {"$ref": "#/definitions/PodSpec"},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+				}
+			},
+			"PodSpec": {"type": "object", "properties": {"nodeName": {"type": "string"}}},
+			"Alias": {"$ref": "#/definitions/Pod"},
+			"A": {"$ref": "#/definitions/B"},
+			"B": {"$ref": "#/definitions/A"}
+		},
+		"parameters": {
+			"body": {"name": "body", "in": "body", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pod"}}},
+			"pretty": {"name": "pretty", "in": "query", "type": "string"}
+		},
+		"responses": {
+			"NotFound": {"description": "not found", "schema": {"type": "string"}}
+		}
+	}`), &swagger))
+
+	tests := []struct {
+		ref           string
+		expected      *Schema
+		expectedError string
+	}{
+		{ref: "#/definitions/PodSpec", expected: swaggerDefinition(&swagger, "PodSpec")},
+		{ref: "#/definitions/Alias", expected: swaggerDefinition(&swagger, "Pod")},
+		{ref: "#/definitions/Pod/properties/spec", expected: swaggerDefinition(&swagger, "PodSpec")},
+		{ref: "#/definitions/Pod/properties/labels/additionalProperties", expected: StringProperty()},
+		{ref: "#/parameters/body/schema/items", expected: swaggerDefinition(&swagger, "Pod")},
+		{ref: "#/parameters/body", expected: ArrayProperty(RefSchema("#/definitions/Pod"))},
+		{ref: "#/responses/NotFound/schema", expected: StringProperty()},
+		{ref: "#/definitions/Missing", expectedError: `cannot resolve reference "#/definitions/Missing": definition "Missing" not found`},
+		{ref: "#/parameters/pretty", expectedError: `cannot resolve reference "#/parameters/pretty": parameter "pretty" has no schema`},
+		{ref: "#/parameters/body/in", expectedError: `cannot resolve reference "#/parameters/body/in": unsupported keyword "in" in /parameters/body/in`},
+		{ref: "#/definitions/A", expectedError: `cannot resolve reference "#/definitions/A": circular reference`},
+		{ref: "#/paths", expectedError: `cannot resolve reference "#/paths": pointer must designate a definition, a parameter or a response`},
+		{ref: "other.json#/definitions/Pod", expectedError: `cannot resolve reference "other.json#/definitions/Pod": only local references are supported`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			schema, err := swagger.ResolveRef(MustCreateRef(tt.ref))
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schema)
+		})
+	}
+}
+
+func swaggerDefinition(swagger *Swagger, name string) *Schema {
+	def := swagger.Definitions[name]
+	return &def
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteJSON writes the same JSON as MarshalJSON to w, without holding the whole encoding in memory:
+// the paths and the definitions, which make up most of a spec, are encoded one at a time.
+func (s *Swagger) WriteJSON(w io.Writer) error {
+	p := &s.SwaggerProps
+	o := &jsonObjectWriter{w: w}
+	o.field("id", p.ID, p.ID == "")
+	o.field("consumes", p.Consumes, len(p.Consumes) == 0)
+	o.field("produces", p.Produces, len(p.Produces) == 0)
+	o.field("schemes", p.Schemes, len(p.Schemes) == 0)
+	o.field("swagger", p.Swagger, p.Swagger == "")
+	o.field("info", p.Info, p.Info == nil)
+	o.field("host", p.Host, p.Host == "")
+	o.field("basePath", p.BasePath, p.BasePath == "")
+	if p.Paths == nil {
+		o.field("paths", nil, false)
+	} else {
+		o.stream("paths", p.Paths.WriteJSON, false)
+	}
+	o.stream("definitions", p.Definitions.WriteJSON, len(p.Definitions) == 0)
+	o.field("parameters", p.Parameters, len(p.Parameters) == 0)
+	o.field("responses", p.Responses, len(p.Responses) == 0)
+	o.field("securityDefinitions", p.SecurityDefinitions, len(p.SecurityDefinitions) == 0)
+	o.field("security", p.Security, len(p.Security) == 0)
+	o.field("tags", p.Tags, len(p.Tags) == 0)
+	o.field("externalDocs", p.ExternalDocs, p.ExternalDocs == nil)
+	o.extensions(s.VendorExtensible)
+	return o.close()
+}
+
+// WriteJSON writes the same JSON as MarshalJSON to w, encoding one path item at a time.
+func (p *Paths) WriteJSON(w io.Writer) error {
+	o := &jsonObjectWriter{w: w}
+	o.extensions(p.VendorExtensible)
+	paths := make([]string, 0, len(p.Paths))
+	for k := range p.Paths {
+		if strings.HasPrefix(k, "/") {
+			paths = append(paths, k)
+		}
+	}
+	sort.Strings(paths)
+	for _, k := range paths {
+		o.field(k, p.Paths[k], false)
+	}
+	return o.close()
+}
+
+// WriteJSON writes the same JSON as json.Marshal to w, encoding one definition at a time.
+func (d Definitions) WriteJSON(w io.Writer) error {
+	o := &jsonObjectWriter{w: w}
+	names := make([]string, 0, len(d))
+	for k := range d {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		o.field(k, d[k], false)
+	}
+	return o.close()
+}
+
+// jsonObjectWriter writes a JSON object member by member. The first error is kept and
+// stops any further writing.
+type jsonObjectWriter struct {
+	w       io.Writer
+	members int
+	err     error
+}
+
+func (o *jsonObjectWriter) write(b []byte) {
+	if o.err == nil {
+		_, o.err = o.w.Write(b)
+	}
+}
+
+// next opens the object or separates the next member from the previous one.
+func (o *jsonObjectWriter) next() {
+	if o.members == 0 {
+		o.write([]byte{'{'})
+	} else {
+		o.write([]byte{','})
+	}
+	o.members++
+}
+
+func (o *jsonObjectWriter) key(name string) {
+	b, err := json.Marshal(name)
+	if err != nil {
+		o.err = err
+		return
+	}
+	o.next()
+	o.write(b)
+	o.write([]byte{':'})
+}
+
+// field writes the member name with the JSON encoding of value, unless omit is true.
+func (o *jsonObjectWriter) field(name string, value interface{}, omit bool) {
+	if omit || o.err != nil {
+		return
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		o.err = err
+		return
+	}
+	o.key(name)
+	o.write(b)
+}
+
+// stream writes the member name with the value written by writeJSON, unless omit is true.
+func (o *jsonObjectWriter) stream(name string, writeJSON func(io.Writer) error, omit bool) {
+	if omit || o.err != nil {
+		return
+	}
+	o.key(name)
+	if o.err == nil {
+		o.err = writeJSON(o.w)
+	}
+}
+
+// extensions writes the vendor extensions as members of the object.
+func (o *jsonObjectWriter) extensions(v VendorExtensible) {
+	if o.err != nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		o.err = err
+		return
+	}
+	if len(b) <= 2 {
+		// no extensions
+		return
+	}
+	o.next()
+	o.write(b[1 : len(b)-1])
+}
+
+func (o *jsonObjectWriter) close() error {
+	if o.members == 0 {
+		o.write([]byte{'{'})
+	}
+	o.write([]byte{'}'})
+	return o.err
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aggregatedSpecPath is a large spec, aggregated from several API servers.
+const aggregatedSpecPath = "../../../test/integration/testdata/aggregator/openapi.json"
+
+func loadAggregatedSpec(t testing.TB) *Swagger {
+	data, err := ioutil.ReadFile(aggregatedSpecPath)
+	require.NoError(t, err)
+	var s Swagger
+	require.NoError(t, json.Unmarshal(data, &s))
+	return &s
+}
+
+func TestSwaggerWriteJSON(t *testing.T) {
+	for name, s := range map[string]*Swagger{
+		"sample":     &spec,
+		"aggregated": loadAggregatedSpec(t),
+		"empty":      {},
+		"extensions only": {
+			SwaggerProps:     SwaggerProps{Paths: &Paths{VendorExtensible: VendorExtensible{Extensions: Extensions{"x-a": "b"}}}},
+			VendorExtensible: VendorExtensible{Extensions: Extensions{"x-c": "<d>"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expected, err := json.Marshal(s)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, s.WriteJSON(&buf))
+			assert.Equal(t, string(expected), buf.String())
+		})
+	}
+}
+
+func BenchmarkSwaggerMarshalJSON(b *testing.B) {
+	s := loadAggregatedSpec(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSwaggerWriteJSON(b *testing.B) {
+	s := loadAggregatedSpec(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.WriteJSON(ioutil.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+// SetTag sets the description and the external documentation of the tag name, adding the tag at the end
+// of the tags of the spec if it is not there yet. The extensions of an existing tag are kept, and other
+// entries with the same name are removed.
+func (s *Swagger) SetTag(name, description string, externalDocs *ExternalDocumentation) {
+	tags := make([]Tag, 0, len(s.Tags)+1)
+	found := false
+	for _, tag := range s.Tags {
+		if tag.Name != name {
+			tags = append(tags, tag)
+			continue
+		}
+		if found {
+			// duplicate
+			continue
+		}
+		found = true
+		tag.Description = description
+		tag.ExternalDocs = externalDocs
+		tags = append(tags, tag)
+	}
+	if !found {
+		tags = append(tags, Tag{TagProps: TagProps{Name: name, Description: description, ExternalDocs: externalDocs}})
+	}
+	s.Tags = tags
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerSetTag(t *testing.T) {
+	docs := &ExternalDocumentation{Description: "API docs", URL: "https://example.com/apps"}
+	s := &Swagger{}
+
+	s.SetTag("core", "Core API", nil)
+	s.SetTag("apps", "Apps API", nil)
+	assert.Equal(t, []Tag{
+		{TagProps: TagProps{Name: "core", Description: "Core API"}},
+		{TagProps: TagProps{Name: "apps", Description: "Apps API"}},
+	}, s.Tags)
+
+	// updating a tag keeps its position, its extensions and the other tags
+	s.Tags[1].AddExtension("x-displayName", "Apps")
+	s.SetTag("apps", "Applications API", docs)
+	assert.Equal(t, []Tag{
+		{TagProps: TagProps{Name: "core", Description: "Core API"}},
+		{
+			VendorExtensible: VendorExtensible{Extensions: Extensions{"x-displayname": "Apps"}},
+			TagProps:         TagProps{Name: "apps", Description: "Applications API", ExternalDocs: docs},
+		},
+	}, s.Tags)
+
+	// duplicates are removed
+	s.Tags = append(s.Tags, Tag{TagProps: TagProps{Name: "core", Description: "Duplicate"}})
+	s.SetTag("core", "Core API v1", nil)
+	assert.Equal(t, []Tag{
+		{TagProps: TagProps{Name: "core", Description: "Core API v1"}},
+		{
+			VendorExtensible: VendorExtensible{Extensions: Extensions{"x-displayname": "Apps"}},
+			TagProps:         TagProps{Name: "apps", Description: "Applications API", ExternalDocs: docs},
+		},
+	}, s.Tags)
+}
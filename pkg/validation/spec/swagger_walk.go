@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	definitionsRefPrefix = "#/definitions/"
+	parametersRefPrefix  = "#/parameters/"
+	responsesRefPrefix   = "#/responses/"
+)
+
+// jsonPointerEscaper escapes a JSON pointer token as defined by RFC 6901, like common.EscapeJsonPointer.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// localRefTarget returns the section ("definitions", "parameters" or "responses") and the unescaped
+// name of the object designated by a local reference, e.g. "definitions" and "io.k8s/Foo" for
+// "#/definitions/io.k8s~1Foo". ok is false for other references.
+func localRefTarget(ref *Ref) (section, name string, ok bool) {
+	refStr := ref.String()
+	if !strings.HasPrefix(refStr, definitionsRefPrefix) && !strings.HasPrefix(refStr, parametersRefPrefix) && !strings.HasPrefix(refStr, responsesRefPrefix) {
+		return "", "", false
+	}
+	tokens := ref.GetPointer().DecodedTokens()
+	if len(tokens) != 2 {
+		return "", "", false
+	}
+	return tokens[0], tokens[1], true
+}
+
+// definitionRef returns the reference to the definition name.
+func definitionRef(name string) Ref {
+	return MustCreateRef(definitionsRefPrefix + jsonPointerEscaper.Replace(name))
+}
+
+// forEachSubSchema calls fn with every direct sub-schema of s, in a deterministic order, along with
+// the keyword holding it and its path relative to s, e.g. "properties" and "properties.foo.", or
+// "allOf" and "allOf[0].". The sub-schemas held by maps are passed as copies, which are written back
+// into their map if fn returns true, i.e. if it modified them.
+func forEachSubSchema(s *Schema, fn func(sub *Schema, keyword, path string) bool) {
+	for _, m := range []struct {
+		keyword string
+		schemas map[string]Schema
+	}{
+		{"definitions", s.Definitions},
+		{"properties", s.Properties},
+		{"patternProperties", s.PatternProperties},
+	} {
+		for _, k := range sortedSchemaKeys(m.schemas) {
+			sch := m.schemas[k]
+			if fn(&sch, m.keyword, m.keyword+"."+k+".") {
+				m.schemas[k] = sch
+			}
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		fn(s.AdditionalProperties.Schema, "additionalProperties", "additionalProperties.")
+	}
+	if s.AdditionalItems != nil && s.AdditionalItems.Schema != nil {
+		fn(s.AdditionalItems.Schema, "additionalItems", "additionalItems.")
+	}
+	if s.Items != nil {
+		if s.Items.Schema != nil {
+			fn(s.Items.Schema, "items", "items.")
+		}
+		for i := range s.Items.Schemas {
+			fn(&s.Items.Schemas[i], "items", fmt.Sprintf("items[%d].", i))
+		}
+	}
+	for _, junctor := range []struct {
+		keyword string
+		schemas []Schema
+	}{
+		{"allOf", s.AllOf},
+		{"anyOf", s.AnyOf},
+		{"oneOf", s.OneOf},
+	} {
+		for i := range junctor.schemas {
+			fn(&junctor.schemas[i], junctor.keyword, fmt.Sprintf("%s[%d].", junctor.keyword, i))
+		}
+	}
+	if s.Not != nil {
+		fn(s.Not, "not", "not.")
+	}
+	deps := make([]string, 0, len(s.Dependencies))
+	for k := range s.Dependencies {
+		deps = append(deps, k)
+	}
+	sort.Strings(deps)
+	for _, k := range deps {
+		if dep := s.Dependencies[k].Schema; dep != nil {
+			fn(dep, "dependencies", "dependencies."+k+".")
+		}
+	}
+}
+
+func sortedSchemaKeys(schemas map[string]Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for k := range schemas {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// refWalker visits the references of the objects of a spec: it is called with every reference, and
+// returns true if it modified the reference in place. The objects holding modified references are
+// written back into the maps of the spec, which is otherwise not modified.
+type refWalker func(ref *Ref) bool
+
+// walkSwagger visits the references of the definitions, global parameters, global responses and
+// paths of s.
+func (w refWalker) walkSwagger(s *Swagger) {
+	for name, def := range s.Definitions {
+		if w.walkSchema(&def) {
+			s.Definitions[name] = def
+		}
+	}
+	for name, param := range s.Parameters {
+		if w.walkParameter(&param) {
+			s.Parameters[name] = param
+		}
+	}
+	for name, resp := range s.Responses {
+		if w.walkResponse(&resp) {
+			s.Responses[name] = resp
+		}
+	}
+	if s.Paths == nil {
+		return
+	}
+	for path, pathItem := range s.Paths.Paths {
+		if w.walkPathItem(&pathItem) {
+			s.Paths.Paths[path] = pathItem
+		}
+	}
+}
+
+func (w refWalker) walkSchema(schema *Schema) bool {
+	if schema == nil {
+		return false
+	}
+	modified := schema.Ref.String() != "" && w(&schema.Ref)
+	forEachSubSchema(schema, func(sub *Schema, _, _ string) bool {
+		if w.walkSchema(sub) {
+			modified = true
+			return true
+		}
+		return false
+	})
+	return modified
+}
+
+func (w refWalker) walkParameter(param *Parameter) bool {
+	modified := param.Ref.String() != "" && w(&param.Ref)
+	if w.walkSchema(param.Schema) {
+		modified = true
+	}
+	if param.Items != nil && param.Items.Ref.String() != "" && w(&param.Items.Ref) {
+		modified = true
+	}
+	return modified
+}
+
+func (w refWalker) walkParameters(params []Parameter) bool {
+	modified := false
+	for i := range params {
+		if w.walkParameter(&params[i]) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (w refWalker) walkResponse(resp *Response) bool {
+	if resp == nil {
+		return false
+	}
+	modified := resp.Ref.String() != "" && w(&resp.Ref)
+	if w.walkSchema(resp.Schema) {
+		modified = true
+	}
+	return modified
+}
+
+func (w refWalker) walkOperation(op *Operation) bool {
+	if op == nil {
+		return false
+	}
+	modified := w.walkParameters(op.Parameters)
+	if op.Responses == nil {
+		return modified
+	}
+	if w.walkResponse(op.Responses.Default) {
+		modified = true
+	}
+	for code, resp := range op.Responses.StatusCodeResponses {
+		if w.walkResponse(&resp) {
+			op.Responses.StatusCodeResponses[code] = resp
+			modified = true
+		}
+	}
+	return modified
+}
+
+func (w refWalker) walkPathItem(pathItem *PathItem) bool {
+	modified := pathItem.Ref.String() != "" && w(&pathItem.Ref)
+	if w.walkParameters(pathItem.Parameters) {
+		modified = true
+	}
+	for _, op := range []*Operation{pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete, pathItem.Options, pathItem.Head, pathItem.Patch} {
+		if w.walkOperation(op) {
+			modified = true
+		}
+	}
+	return modified
+}
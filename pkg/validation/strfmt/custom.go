@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strfmt
+
+import "fmt"
+
+// AddValidator registers in the registry a string format which is only defined by its validator,
+// i.e. without a dedicated Go type: the values of the format are parsed as CustomString.
+// The example must be accepted by the validator; it guards against registering a validator
+// which rejects everything. A format already registered under the same name is replaced.
+//
+// The formats added to Default are checked by the validate package, e.g.
+//
+//	strfmt.AddValidator(strfmt.Default, "k8s-resource-quantity", isQuantity, "500m")
+func AddValidator(registry Registry, name string, validator Validator, example string) error {
+	if validator == nil {
+		return fmt.Errorf("no validator for format %q", name)
+	}
+	if !validator(example) {
+		return fmt.Errorf("example %q is not a valid %s", example, name)
+	}
+	registry.Add(name, new(CustomString), validator)
+	return nil
+}
+
+// CustomString represents a value of a string format registered with AddValidator.
+type CustomString string
+
+// MarshalText turns this instance into text
+func (c CustomString) MarshalText() ([]byte, error) {
+	return []byte(string(c)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (c *CustomString) UnmarshalText(data []byte) error { // validation is performed later on
+	*c = CustomString(string(data))
+	return nil
+}
+
+func (c CustomString) String() string {
+	return string(c)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strfmt
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var isDummyQuantity = regexp.MustCompile(`^[0-9]+m?$`).MatchString
+
+func TestAddValidator(t *testing.T) {
+	registry := NewFormats()
+	require.NoError(t, AddValidator(registry, "dummy-quantity", isDummyQuantity, "500m"))
+
+	assert.True(t, registry.ContainsName("dummy-quantity"))
+	assert.True(t, registry.Validates("dummy-quantity", "12"))
+	assert.False(t, registry.Validates("dummy-quantity", "12k"))
+	assert.False(t, Default.ContainsName("dummy-quantity"))
+
+	v, err := registry.Parse("dummy-quantity", "500m")
+	require.NoError(t, err)
+	assert.Equal(t, CustomString("500m"), *v.(*CustomString))
+
+	assert.Error(t, AddValidator(registry, "bad-quantity", isDummyQuantity, "lots"))
+	assert.False(t, registry.ContainsName("bad-quantity"))
+	assert.Error(t, AddValidator(registry, "nil-quantity", nil, ""))
+}
@@ -19,8 +19,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/mail"
+	"net/url"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/asaskevich/govalidator"
 )
@@ -122,6 +124,137 @@ func IsEmail(str string) bool {
 	return e == nil && addr.Address != ""
 }
 
+// IsJSONPointer returns true when the string is a JSON pointer, as defined by RFC 6901.
+// The empty string is a valid pointer to the whole document.
+func IsJSONPointer(str string) bool {
+	if str == "" {
+		return true
+	}
+	if str[0] != '/' {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] == '~' && (i+1 == len(str) || (str[i+1] != '0' && str[i+1] != '1')) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRelativeJSONPointer returns true when the string is a relative JSON pointer, i.e. a non-negative integer
+// without leading zeros followed by either "#" or a JSON pointer.
+// See https://tools.ietf.org/html/draft-handrews-relative-json-pointer-01
+func IsRelativeJSONPointer(str string) bool {
+	i := 0
+	for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+		i++
+	}
+	if i == 0 || (i > 1 && str[0] == '0') {
+		return false
+	}
+	return str[i:] == "#" || IsJSONPointer(str[i:])
+}
+
+// IsIRI returns true when the string is an absolute IRI, as defined by RFC 3987, i.e. a URI in which
+// non-ASCII characters may appear unencoded.
+func IsIRI(str string) bool {
+	if !IsIRIReference(str) {
+		return false
+	}
+	u, _ := url.Parse(str)
+	return u.IsAbs()
+}
+
+// IsIRIReference returns true when the string is an IRI or a relative IRI reference, as defined by RFC 3987.
+// The check is pragmatic: the string must parse as a URL and contain neither spaces nor control characters.
+func IsIRIReference(str string) bool {
+	for _, r := range str {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	_, err := url.Parse(str)
+	return err == nil
+}
+
+// IsURITemplate returns true when the string is a URI template, as defined by RFC 6570,
+// e.g. "/pets/{id}{?fields*}".
+func IsURITemplate(str string) bool {
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; {
+		case c == '{':
+			end := strings.IndexByte(str[i:], '}')
+			if end < 0 || !isURITemplateExpression(str[i+1:i+end]) {
+				return false
+			}
+			i += end
+		case c == '%':
+			if !isPctEncoded(str[i:]) {
+				return false
+			}
+			i += 2
+		case c <= ' ' || c == 0x7f || strings.IndexByte("\"'<>\\^`|}", c) >= 0:
+			return false
+		}
+	}
+	return true
+}
+
+// isURITemplateExpression returns true if expr, the content of braces of a URI template, is an operator
+// followed by a comma separated list of variables, each with an optional prefix or explode modifier.
+func isURITemplateExpression(expr string) bool {
+	if expr != "" && strings.IndexByte("+#./;?&=,!@|", expr[0]) >= 0 {
+		expr = expr[1:]
+	}
+	for _, varspec := range strings.Split(expr, ",") {
+		name := varspec
+		if strings.HasSuffix(varspec, "*") {
+			name = varspec[:len(varspec)-1]
+		} else if i := strings.IndexByte(varspec, ':'); i >= 0 {
+			name = varspec[:i]
+			length := varspec[i+1:]
+			if len(length) == 0 || len(length) > 4 || length[0] == '0' || strings.Trim(length, "0123456789") != "" {
+				return false
+			}
+		}
+		if !isURITemplateVarname(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func isURITemplateVarname(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' || strings.Contains(name, "..") {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '.':
+		case c == '%' && isPctEncoded(name[i:]):
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isPctEncoded returns true if str starts with a percent-encoded octet, e.g. "%2F".
+func isPctEncoded(str string) bool {
+	isHex := func(c byte) bool {
+		return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+	}
+	return len(str) >= 3 && str[0] == '%' && isHex(str[1]) && isHex(str[2])
+}
+
+// IsRegex returns true when the string is a regular expression accepted by regexp.Compile.
+// The empty string is a valid regular expression, matching everything.
+func IsRegex(str string) bool {
+	_, err := regexp.Compile(str)
+	return err == nil
+}
+
 func init() {
 	// register formats in the default registry:
 	//   - byte
@@ -132,14 +265,20 @@ func init() {
 	//   - ipv4
 	//   - ipv6
 	//   - cidr
+	//   - iri
+	//   - iri-reference
 	//   - isbn
 	//   - isbn10
 	//   - isbn13
+	//   - json-pointer
 	//   - mac
 	//   - password
+	//   - regex
+	//   - relative-json-pointer
 	//   - rgbcolor
 	//   - ssn
 	//   - uri
+	//   - uri-template
 	//   - uuid
 	//   - uuid3
 	//   - uuid4
@@ -147,6 +286,15 @@ func init() {
 	u := URI("")
 	Default.Add("uri", &u, govalidator.IsRequestURI)
 
+	iri := IRI("")
+	Default.Add("iri", &iri, IsIRI)
+
+	iriRef := IRIReference("")
+	Default.Add("iri-reference", &iriRef, IsIRIReference)
+
+	ut := URITemplate("")
+	Default.Add("uri-template", &ut, IsURITemplate)
+
 	eml := Email("")
 	Default.Add("email", &eml, IsEmail)
 
@@ -203,6 +351,15 @@ func init() {
 
 	pw := Password("")
 	Default.Add("password", &pw, func(_ string) bool { return true })
+
+	jp := JSONPointer("")
+	Default.Add("json-pointer", &jp, IsJSONPointer)
+
+	rjp := RelativeJSONPointer("")
+	Default.Add("relative-json-pointer", &rjp, IsRelativeJSONPointer)
+
+	rx := Regex("")
+	Default.Add("regex", &rx, IsRegex)
 }
 
 // Base64 represents a base64 encoded string, using URLEncoding alphabet
@@ -1546,3 +1703,405 @@ func (r *Password) DeepCopy() *Password {
 	r.DeepCopyInto(out)
 	return out
 }
+
+// JSONPointer represents a JSON pointer as specified by RFC 6901.
+//
+// swagger:strfmt json-pointer
+type JSONPointer string
+
+// MarshalText turns this instance into text
+func (r JSONPointer) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *JSONPointer) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = JSONPointer(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *JSONPointer) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = JSONPointer(string(v))
+	case string:
+		*r = JSONPointer(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.JSONPointer from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r JSONPointer) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the JSONPointer as JSON
+func (r JSONPointer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the JSONPointer from JSON
+func (r *JSONPointer) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = JSONPointer(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *JSONPointer) DeepCopyInto(out *JSONPointer) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new JSONPointer.
+func (r *JSONPointer) DeepCopy() *JSONPointer {
+	if r == nil {
+		return nil
+	}
+	out := new(JSONPointer)
+	r.DeepCopyInto(out)
+	return out
+}
+
+// RelativeJSONPointer represents a relative JSON pointer.
+//
+// swagger:strfmt relative-json-pointer
+type RelativeJSONPointer string
+
+// MarshalText turns this instance into text
+func (r RelativeJSONPointer) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *RelativeJSONPointer) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = RelativeJSONPointer(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *RelativeJSONPointer) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = RelativeJSONPointer(string(v))
+	case string:
+		*r = RelativeJSONPointer(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.RelativeJSONPointer from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r RelativeJSONPointer) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the RelativeJSONPointer as JSON
+func (r RelativeJSONPointer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the RelativeJSONPointer from JSON
+func (r *RelativeJSONPointer) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = RelativeJSONPointer(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *RelativeJSONPointer) DeepCopyInto(out *RelativeJSONPointer) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new RelativeJSONPointer.
+func (r *RelativeJSONPointer) DeepCopy() *RelativeJSONPointer {
+	if r == nil {
+		return nil
+	}
+	out := new(RelativeJSONPointer)
+	r.DeepCopyInto(out)
+	return out
+}
+
+// Regex represents a regular expression, in the syntax of the Go regexp package.
+//
+// swagger:strfmt regex
+type Regex string
+
+// MarshalText turns this instance into text
+func (r Regex) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *Regex) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = Regex(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *Regex) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = Regex(string(v))
+	case string:
+		*r = Regex(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.Regex from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r Regex) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the Regex as JSON
+func (r Regex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the Regex from JSON
+func (r *Regex) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = Regex(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *Regex) DeepCopyInto(out *Regex) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new Regex.
+func (r *Regex) DeepCopy() *Regex {
+	if r == nil {
+		return nil
+	}
+	out := new(Regex)
+	r.DeepCopyInto(out)
+	return out
+}
+
+// IRI represents an internationalized resource identifier, as specified by RFC 3987.
+//
+// swagger:strfmt iri
+type IRI string
+
+// MarshalText turns this instance into text
+func (r IRI) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *IRI) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = IRI(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *IRI) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = IRI(string(v))
+	case string:
+		*r = IRI(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.IRI from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r IRI) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the IRI as JSON
+func (r IRI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the IRI from JSON
+func (r *IRI) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = IRI(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *IRI) DeepCopyInto(out *IRI) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new IRI.
+func (r *IRI) DeepCopy() *IRI {
+	if r == nil {
+		return nil
+	}
+	out := new(IRI)
+	r.DeepCopyInto(out)
+	return out
+}
+
+// IRIReference represents an IRI or a relative IRI reference, as specified by RFC 3987.
+//
+// swagger:strfmt iri-reference
+type IRIReference string
+
+// MarshalText turns this instance into text
+func (r IRIReference) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *IRIReference) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = IRIReference(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *IRIReference) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = IRIReference(string(v))
+	case string:
+		*r = IRIReference(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.IRIReference from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r IRIReference) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the IRIReference as JSON
+func (r IRIReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the IRIReference from JSON
+func (r *IRIReference) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = IRIReference(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *IRIReference) DeepCopyInto(out *IRIReference) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new IRIReference.
+func (r *IRIReference) DeepCopy() *IRIReference {
+	if r == nil {
+		return nil
+	}
+	out := new(IRIReference)
+	r.DeepCopyInto(out)
+	return out
+}
+
+// URITemplate represents a URI template, as specified by RFC 6570.
+//
+// swagger:strfmt uri-template
+type URITemplate string
+
+// MarshalText turns this instance into text
+func (r URITemplate) MarshalText() ([]byte, error) {
+	return []byte(string(r)), nil
+}
+
+// UnmarshalText hydrates this instance from text
+func (r *URITemplate) UnmarshalText(data []byte) error { // validation is performed later on
+	*r = URITemplate(string(data))
+	return nil
+}
+
+// Scan read a value from a database driver
+func (r *URITemplate) Scan(raw interface{}) error {
+	switch v := raw.(type) {
+	case []byte:
+		*r = URITemplate(string(v))
+	case string:
+		*r = URITemplate(v)
+	default:
+		return fmt.Errorf("cannot sql.Scan() strfmt.URITemplate from: %#v", v)
+	}
+
+	return nil
+}
+
+func (r URITemplate) String() string {
+	return string(r)
+}
+
+// MarshalJSON returns the URITemplate as JSON
+func (r URITemplate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(r))
+}
+
+// UnmarshalJSON sets the URITemplate from JSON
+func (r *URITemplate) UnmarshalJSON(data []byte) error {
+	if string(data) == jsonNull {
+		return nil
+	}
+	var ustr string
+	if err := json.Unmarshal(data, &ustr); err != nil {
+		return err
+	}
+	*r = URITemplate(ustr)
+	return nil
+}
+
+// DeepCopyInto copies the receiver and writes its value into out.
+func (r *URITemplate) DeepCopyInto(out *URITemplate) {
+	*out = *r
+}
+
+// DeepCopy copies the receiver into a new URITemplate.
+func (r *URITemplate) DeepCopy() *URITemplate {
+	if r == nil {
+		return nil
+	}
+	out := new(URITemplate)
+	r.DeepCopyInto(out)
+	return out
+}
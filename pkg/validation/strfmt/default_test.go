@@ -271,6 +271,129 @@ func TestFormatPassword(t *testing.T) {
 	testStringFormat(t, &password, "password", "super secret!!!", []string{"even more secret"}, []string{})
 }
 
+func TestFormatJSONPointer(t *testing.T) {
+	pointer := JSONPointer("/foo")
+	validPointers := []string{
+		"",
+		"/",
+		"/foo/0",
+		"/a~1b",
+		"/m~0n",
+		"/ ",
+		"/c%d",
+	}
+	invalidPointers := []string{
+		"foo",
+		"#/foo",
+		"/foo~",
+		"/foo~2",
+		"/~a",
+	}
+	testStringFormat(t, &pointer, "json-pointer", "/foo/bar", validPointers, invalidPointers)
+}
+
+func TestFormatRelativeJSONPointer(t *testing.T) {
+	pointer := RelativeJSONPointer("0")
+	validPointers := []string{
+		"0",
+		"1/0",
+		"2/highly/nested/objects",
+		"0#",
+		"1#",
+		"10/a~1b",
+	}
+	invalidPointers := []string{
+		"",
+		"/foo",
+		"01/foo",
+		"-1/foo",
+		"0foo",
+		"1/foo~",
+		"1##",
+		"#",
+	}
+	testStringFormat(t, &pointer, "relative-json-pointer", "0/foo/bar", validPointers, invalidPointers)
+}
+
+func TestFormatRegex(t *testing.T) {
+	rx := Regex("^a+$")
+	validRegexes := []string{
+		"",
+		"[a-z0-9]([-a-z0-9]*[a-z0-9])?",
+		"(?i)foo|bar",
+	}
+	invalidRegexes := []string{
+		"(foo",
+		"foo)",
+		"[a-z",
+		"a**",
+	}
+	testStringFormat(t, &rx, "regex", "^[a-z]+$", validRegexes, invalidRegexes)
+}
+
+func TestFormatIRI(t *testing.T) {
+	iri := IRI("https://example.com")
+	validIRIs := []string{
+		"https://例え.テスト/パス?q=値#frag",
+		"urn:isbn:0451450523",
+		"http://example.com/%E2%82%AC",
+	}
+	invalidIRIs := []string{
+		"",
+		"/relative/path",
+		"http://example.com/with space",
+		"http://example.com/\u0007",
+		"http://[::1",
+	}
+	testStringFormat(t, &iri, "iri", "https://example.com/ünïcödé", validIRIs, invalidIRIs)
+}
+
+func TestFormatIRIReference(t *testing.T) {
+	iriRef := IRIReference("/path")
+	validIRIRefs := []string{
+		"",
+		"/relative/パス",
+		"../up#frag",
+		"https://例え.テスト",
+	}
+	invalidIRIRefs := []string{
+		"with space",
+		"tab\there",
+		"http://[::1",
+	}
+	testStringFormat(t, &iriRef, "iri-reference", "./ünïcödé", validIRIRefs, invalidIRIRefs)
+}
+
+func TestFormatURITemplate(t *testing.T) {
+	template := URITemplate("/pets")
+	validTemplates := []string{
+		"",
+		"http://example.com/",
+		"/pets/{id}",
+		"{+path}/here",
+		"{/var,x}/here{?x,y*}",
+		"{#keys*}",
+		"/search{?q,lang:2}{&page}",
+		"{var.name}",
+		"/%E2%82%AC/{id}",
+		"/ünïcödé/{id}",
+	}
+	invalidTemplates := []string{
+		"/pets/{id",
+		"/pets/id}",
+		"/pets/{}",
+		"/pets/{i d}",
+		"/pets/{id:0}",
+		"/pets/{id:10000}",
+		"/pets/{.}",
+		"/pets/{a..b}",
+		"/with space",
+		"/%zz",
+		"/<id>",
+	}
+	testStringFormat(t, &template, "uri-template", "/pets/{id}{?fields*}", validTemplates, invalidTemplates)
+}
+
 func TestFormatBase64(t *testing.T) {
 	const b64 string = "This is a byte array with unprintable chars, but it also isn"
 	str := base64.URLEncoding.EncodeToString([]byte(b64))
@@ -685,3 +808,99 @@ func TestDeepCopyPassword(t *testing.T) {
 	out3 := inNil.DeepCopy()
 	assert.Nil(t, out3)
 }
+
+func TestDeepCopyJSONPointer(t *testing.T) {
+	pointer := JSONPointer("/foo/bar")
+	in := &pointer
+
+	out := new(JSONPointer)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *JSONPointer
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
+
+func TestDeepCopyRelativeJSONPointer(t *testing.T) {
+	pointer := RelativeJSONPointer("1/foo")
+	in := &pointer
+
+	out := new(RelativeJSONPointer)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *RelativeJSONPointer
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
+
+func TestDeepCopyRegex(t *testing.T) {
+	rx := Regex("^a+$")
+	in := &rx
+
+	out := new(Regex)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *Regex
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
+
+func TestDeepCopyIRI(t *testing.T) {
+	value := IRI("https://example.com/ünïcödé")
+	in := &value
+
+	out := new(IRI)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *IRI
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
+
+func TestDeepCopyIRIReference(t *testing.T) {
+	value := IRIReference("./ünïcödé")
+	in := &value
+
+	out := new(IRIReference)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *IRIReference
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
+
+func TestDeepCopyURITemplate(t *testing.T) {
+	value := URITemplate("/pets/{id}")
+	in := &value
+
+	out := new(URITemplate)
+	in.DeepCopyInto(out)
+	assert.Equal(t, in, out)
+
+	out2 := in.DeepCopy()
+	assert.Equal(t, in, out2)
+
+	var inNil *URITemplate
+	out3 := inNil.DeepCopy()
+	assert.Nil(t, out3)
+}
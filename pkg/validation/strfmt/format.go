@@ -160,6 +160,18 @@ func (f *defaultFormats) MapStructureHookFunc() mapstructure.DecodeHookFunc {
 					return Base64(data.(string)), nil
 				case "password":
 					return Password(data.(string)), nil
+				case "json-pointer":
+					return JSONPointer(data.(string)), nil
+				case "relative-json-pointer":
+					return RelativeJSONPointer(data.(string)), nil
+				case "regex":
+					return Regex(data.(string)), nil
+				case "iri":
+					return IRI(data.(string)), nil
+				case "iri-reference":
+					return IRIReference(data.(string)), nil
+				case "uri-template":
+					return URITemplate(data.(string)), nil
 				default:
 					return nil, errors.InvalidTypeName(v.Name)
 				}
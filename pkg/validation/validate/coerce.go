@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"time"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+)
+
+// AgainstSchemaWithCoercion validates data, a value decoded from JSON, against the schema like
+// AgainstSchema, after coercing a copy of data to the canonical form of its schema. The coerced
+// value is returned if valid. The coercions are enabled with options, i.e.:
+//   - WithNormalizedDateTimes converts the date-times to UTC.
+//
+// Like ApplyDefaults, coercion descends into the properties, additionalProperties and items of
+// the schema; $refs are not followed.
+func AgainstSchemaWithCoercion(schema *spec.Schema, data interface{}, formats strfmt.Registry, options ...Option) (interface{}, error) {
+	opts := &SchemaValidatorOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	coerced := coerce(schema, deepCopyJSON(data), opts)
+	if err := AgainstSchema(schema, coerced, formats, options...); err != nil {
+		return nil, err
+	}
+	return coerced, nil
+}
+
+// coerce returns data coerced to the canonical form of the schema, modifying maps and slices in place.
+func coerce(schema *spec.Schema, data interface{}, opts *SchemaValidatorOptions) interface{} {
+	if schema == nil {
+		return data
+	}
+	switch d := data.(type) {
+	case string:
+		if opts.NormalizeDateTimes && strfmt.DefaultNameNormalizer(schema.Format) == "datetime" {
+			// invalid date-times are left to the format validator
+			if t, err := strfmt.ParseDateTime(d); err == nil {
+				return time.Time(t).UTC().Format(time.RFC3339Nano)
+			}
+		}
+	case map[string]interface{}:
+		for name, v := range d {
+			if prop, ok := schema.Properties[name]; ok {
+				d[name] = coerce(&prop, v, opts)
+			} else if schema.AdditionalProperties != nil {
+				d[name] = coerce(schema.AdditionalProperties.Schema, v, opts)
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil {
+			return data
+		}
+		for i, v := range d {
+			if schema.Items.Schema != nil {
+				d[i] = coerce(schema.Items.Schema, v, opts)
+			} else if i < len(schema.Items.Schemas) {
+				d[i] = coerce(&schema.Items.Schemas[i], v, opts)
+			}
+		}
+	}
+	return data
+}
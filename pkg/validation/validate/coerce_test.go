@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+)
+
+func TestAgainstSchemaWithCoercion_DateTimes(t *testing.T) {
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"created": {"type": "string", "format": "date-time"},
+			"name": {"type": "string"},
+			"events": {"type": "array", "items": {"type": "string", "format": "date-time"}}
+		}
+	}`), schema))
+
+	data := map[string]interface{}{
+		"created": "2020-06-01T10:30:00.5+02:00",
+		"name":    "2020-06-01T10:30:00+02:00",
+		"events":  []interface{}{"2020-06-01T23:00:00-05:00"},
+	}
+	coerced, err := AgainstSchemaWithCoercion(schema, data, strfmt.Default, WithNormalizedDateTimes(true))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"created": "2020-06-01T08:30:00.5Z",
+		"name":    "2020-06-01T10:30:00+02:00",
+		"events":  []interface{}{"2020-06-02T04:00:00Z"},
+	}, coerced)
+	// the data is not modified
+	assert.Equal(t, "2020-06-01T10:30:00.5+02:00", data["created"])
+
+	// without the option, the date-times are kept as is
+	coerced, err = AgainstSchemaWithCoercion(schema, data, strfmt.Default)
+	require.NoError(t, err)
+	assert.Equal(t, data, coerced)
+
+	_, err = AgainstSchemaWithCoercion(schema, map[string]interface{}{"created": "yesterday"}, strfmt.Default, WithNormalizedDateTimes(true))
+	assert.Error(t, err)
+}
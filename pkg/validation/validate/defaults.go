@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// ApplyDefaults sets the defaults of the properties described by schema in data, a value decoded
+// from JSON, e.g. by json.Unmarshal into an interface{}. It descends into the properties,
+// additionalProperties and items of the schema; $refs are not followed.
+//
+// Defaults only apply to absent properties, unless WithDefaultEmptyValues is set, in which case
+// properties present with an empty value are defaulted too. Present values are always kept.
+func ApplyDefaults(schema *spec.Schema, data interface{}, options ...Option) {
+	opts := &SchemaValidatorOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	applyDefaults(schema, data, opts)
+}
+
+func applyDefaults(schema *spec.Schema, data interface{}, opts *SchemaValidatorOptions) {
+	if schema == nil {
+		return
+	}
+	switch d := data.(type) {
+	case map[string]interface{}:
+		for name := range schema.Properties {
+			prop := schema.Properties[name]
+			v, ok := d[name]
+			if prop.Default != nil && (!ok || opts.DefaultEmptyValues && isEmptyValue(v)) {
+				v = deepCopyJSON(prop.Default)
+				d[name] = v
+			}
+			applyDefaults(&prop, v, opts)
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			for name, v := range d {
+				if _, ok := schema.Properties[name]; !ok {
+					applyDefaults(schema.AdditionalProperties.Schema, v, opts)
+				}
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil {
+			return
+		}
+		for i, v := range d {
+			if schema.Items.Schema != nil {
+				applyDefaults(schema.Items.Schema, v, opts)
+			} else if i < len(schema.Items.Schemas) {
+				applyDefaults(&schema.Items.Schemas[i], v, opts)
+			}
+		}
+	}
+}
+
+// isEmptyValue returns true for null and for the zero values of JSON: "", 0, false, [] and {}.
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return err == nil && f == 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	}
+	return false
+}
+
+// deepCopyJSON copies the maps and slices of a value decoded from JSON, so that the defaults of
+// the schema are not shared with, nor modified through, the data.
+func deepCopyJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = deepCopyJSON(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = deepCopyJSON(e)
+		}
+		return out
+	}
+	return v
+}
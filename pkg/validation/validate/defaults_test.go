@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "default": "unnamed"},
+			"replicas": {"type": "integer", "default": 1},
+			"labels": {"type": "object", "default": {"app": "x"}},
+			"containers": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"pull": {"type": "string", "default": "Always"}}
+				}
+			}
+		}
+	}`), schema))
+
+	tests := []struct {
+		name     string
+		data     string
+		options  []Option
+		expected string
+	}{
+		{
+			name:     "absent",
+			data:     `{"containers": [{}]}`,
+			expected: `{"name": "unnamed", "replicas": 1, "labels": {"app": "x"}, "containers": [{"pull": "Always"}]}`,
+		},
+		{
+			name:     "present empty",
+			data:     `{"name": "", "replicas": 0, "labels": null, "containers": [{"pull": ""}]}`,
+			expected: `{"name": "", "replicas": 0, "labels": null, "containers": [{"pull": ""}]}`,
+		},
+		{
+			name:     "present empty with empty values defaulted",
+			data:     `{"name": "", "replicas": 0, "labels": {}, "containers": [{"pull": ""}]}`,
+			options:  []Option{WithDefaultEmptyValues(true)},
+			expected: `{"name": "unnamed", "replicas": 1, "labels": {"app": "x"}, "containers": [{"pull": "Always"}]}`,
+		},
+		{
+			name:     "present value",
+			data:     `{"name": "foo", "replicas": 3, "labels": {"app": "y"}, "containers": [{"pull": "Never"}]}`,
+			options:  []Option{WithDefaultEmptyValues(true)},
+			expected: `{"name": "foo", "replicas": 3, "labels": {"app": "y"}, "containers": [{"pull": "Never"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+			ApplyDefaults(schema, data, tt.options...)
+			actual, err := json.Marshal(data)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+
+	// defaults are copied, not shared with the data
+	var data interface{} = map[string]interface{}{}
+	ApplyDefaults(schema, data)
+	data.(map[string]interface{})["labels"].(map[string]interface{})["app"] = "changed"
+	assert.Equal(t, map[string]interface{}{"app": "x"}, schema.Properties["labels"].Default)
+}
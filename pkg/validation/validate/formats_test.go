@@ -2,6 +2,7 @@ package validate
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,3 +34,14 @@ func TestFormatValidator_EdgeCases(t *testing.T) {
 	assert.False(t, v.Applies("A string", reflect.String))
 	assert.False(t, v.Applies(nil, reflect.String))
 }
+
+func TestFormatValidator_AddedFormat(t *testing.T) {
+	registry := strfmt.NewFormats()
+	isDummy := func(s string) bool { return strings.HasPrefix(s, "dummy-") }
+	assert.NoError(t, strfmt.AddValidator(registry, "dummy", isDummy, "dummy-example"))
+
+	s := spec.StringProperty()
+	s.Format = "dummy"
+	assert.NoError(t, AgainstSchema(s, "dummy-value", registry))
+	assert.Error(t, AgainstSchema(s, "value", registry))
+}
@@ -67,6 +67,13 @@ const (
 	stringFormatUUID4      = "uuid4"
 	stringFormatUUID5      = "uuid5"
 
+	stringFormatJSONPointer         = "json-pointer"
+	stringFormatRelativeJSONPointer = "relative-json-pointer"
+	stringFormatRegex               = "regex"
+	stringFormatIRI                 = "iri"
+	stringFormatIRIReference        = "iri-reference"
+	stringFormatURITemplate         = "uri-template"
+
 	integerFormatInt32  = "int32"
 	integerFormatInt64  = "int64"
 	integerFormatUInt32 = "uint32"
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"reflect"
+	"sort"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// KeywordValidator validates a value against a custom keyword of its schema, i.e. against
+// the extension of the schema registered for the validator in Keywords.
+type KeywordValidator func(schema *spec.Schema, value interface{}) *Result
+
+// Keywords registers the validators of custom keywords, keyed by the name of the schema
+// extension holding the keyword, e.g. "x-forbidden-values". They are invoked when validating
+// values against schemas having that extension, see WithKeywords.
+type Keywords map[string]KeywordValidator
+
+// Register sets the validator of the custom keyword held by the extension name.
+func (k Keywords) Register(name string, validator KeywordValidator) {
+	k[name] = validator
+}
+
+// keywordValidator invokes the validators of the custom keywords of a schema, in the order of their names.
+type keywordValidator struct {
+	Schema   *spec.Schema
+	Keywords Keywords
+}
+
+func (k *keywordValidator) SetPath(path string) {}
+
+func (k *keywordValidator) Applies(source interface{}, kind reflect.Kind) bool {
+	if _, ok := source.(*spec.Schema); !ok {
+		return false
+	}
+	for name := range k.Keywords {
+		if _, ok := k.Schema.Extensions[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *keywordValidator) Validate(data interface{}) *Result {
+	names := make([]string, 0, len(k.Keywords))
+	for name := range k.Keywords {
+		if _, ok := k.Schema.Extensions[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := new(Result)
+	for _, name := range names {
+		result.Merge(k.Keywords[name](k.Schema, data))
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+)
+
+func TestSchemaValidator_Keywords(t *testing.T) {
+	keywords := Keywords{}
+	keywords.Register("x-forbidden-values", func(schema *spec.Schema, value interface{}) *Result {
+		result := new(Result)
+		for _, forbidden := range schema.Extensions["x-forbidden-values"].([]interface{}) {
+			if value == forbidden {
+				result.AddErrors(errors.FailedRule("", "body", "x-forbidden-values", value))
+			}
+		}
+		return result
+	})
+
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "maxLength": 5, "x-forbidden-values": ["root", "admin"]},
+			"other": {"type": "string", "x-other": ["root"]}
+		}
+	}`), schema))
+
+	assert.NoError(t, AgainstSchema(schema, map[string]interface{}{"name": "user", "other": "root"}, strfmt.Default, WithKeywords(keywords)))
+	assert.Error(t, AgainstSchema(schema, map[string]interface{}{"name": "root"}, strfmt.Default, WithKeywords(keywords)))
+	// built-in keywords are still validated
+	assert.Error(t, AgainstSchema(schema, map[string]interface{}{"name": "nobody"}, strfmt.Default, WithKeywords(keywords)))
+	// custom keywords are ignored when not registered
+	assert.NoError(t, AgainstSchema(schema, map[string]interface{}{"name": "root"}, strfmt.Default))
+}
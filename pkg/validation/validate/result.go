@@ -16,6 +16,7 @@ package validate
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/kube-openapi/pkg/validation/errors"
@@ -37,6 +38,10 @@ type Result struct {
 	Errors     []error
 	Warnings   []error
 	MatchCount int
+
+	// Truncated is set when the validation stopped after exceeding the maximum
+	// number of errors (see WithMaxErrors): errors were dropped.
+	Truncated bool
 }
 
 // Merge merges this result with the other one(s), preserving match counts etc.
@@ -46,6 +51,7 @@ func (r *Result) Merge(others ...*Result) *Result {
 			r.AddErrors(other.Errors...)
 			r.AddWarnings(other.Warnings...)
 			r.MatchCount += other.MatchCount
+			r.Truncated = r.Truncated || other.Truncated
 		}
 	}
 	return r
@@ -60,6 +66,7 @@ func (r *Result) MergeAsErrors(others ...*Result) *Result {
 			r.AddErrors(other.Errors...)
 			r.AddErrors(other.Warnings...)
 			r.MatchCount += other.MatchCount
+			r.Truncated = r.Truncated || other.Truncated
 		}
 	}
 	return r
@@ -74,6 +81,7 @@ func (r *Result) MergeAsWarnings(others ...*Result) *Result {
 			r.AddWarnings(other.Errors...)
 			r.AddWarnings(other.Warnings...)
 			r.MatchCount += other.MatchCount
+			r.Truncated = r.Truncated || other.Truncated
 		}
 	}
 	return r
@@ -205,3 +213,246 @@ func (r *Result) AsError() error {
 	}
 	return errors.CompositeValidationError(r.Errors...)
 }
+
+// ErrorClass tells apart the errors caused by an invalid schema from the errors caused by an invalid value.
+type ErrorClass int
+
+const (
+	// DataError is an error of the validated value, e.g. a value of the wrong type: the input must be fixed.
+	DataError ErrorClass = iota
+	// SchemaError is an error of the schema, e.g. a pattern which is not a valid regular expression or a
+	// $ref which cannot be resolved: the schema must be fixed, the value could not be fully validated.
+	SchemaError
+)
+
+// ClassifyError returns the class of an error of a validation result.
+func ClassifyError(err error) ErrorClass {
+	switch e := err.(type) {
+	case *errors.Validation:
+		if e.InvalidSchema {
+			return SchemaError
+		}
+		switch e.Code() {
+		case errors.InvalidRuleCode, errors.MultipleOfMustBePositiveCode:
+			return SchemaError
+		}
+		return DataError
+	case errors.Error:
+		return DataError
+	}
+	// other errors are reported when the schema cannot be used at all, e.g. for unresolvable $refs
+	return SchemaError
+}
+
+// SchemaErrors returns the errors of the result caused by an invalid schema, see ClassifyError.
+func (r *Result) SchemaErrors() []error {
+	return r.errorsOfClass(SchemaError)
+}
+
+// DataErrors returns the errors of the result caused by an invalid value, see ClassifyError.
+func (r *Result) DataErrors() []error {
+	return r.errorsOfClass(DataError)
+}
+
+func (r *Result) errorsOfClass(class ErrorClass) []error {
+	var ret []error
+	for _, e := range r.Errors {
+		if ClassifyError(e) == class {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// maxAggregatedSamplePaths is the number of paths reported by an aggregated error
+const maxAggregatedSamplePaths = 3
+
+// aggregateErrors collapses validation errors sharing the same code and path pattern
+// into a single error reporting their count. Errors which do not carry a path, or
+// which do not share their pattern with any other error, are kept untouched.
+//
+// The order of the first occurrence of each group is preserved.
+func aggregateErrors(errs []error) []error {
+	type group struct {
+		errors []*errors.Validation
+		index  int
+	}
+	type key struct {
+		code    int32
+		in      string
+		pattern string
+	}
+	groups := map[key]*group{}
+	ret := make([]error, 0, len(errs))
+	for _, e := range errs {
+		v, ok := e.(*errors.Validation)
+		if !ok || v.Name == "" {
+			ret = append(ret, e)
+			continue
+		}
+		k := key{code: v.Code(), in: v.In, pattern: pathPattern(v.Name)}
+		g, ok := groups[k]
+		if !ok {
+			g = &group{index: len(ret)}
+			groups[k] = g
+			ret = append(ret, e)
+		}
+		g.errors = append(g.errors, v)
+	}
+	for k, g := range groups {
+		if len(g.errors) < 2 {
+			continue
+		}
+		samplePaths := []string{}
+		for _, v := range g.errors {
+			if len(samplePaths) == maxAggregatedSamplePaths {
+				break
+			}
+			samplePaths = append(samplePaths, v.Name)
+		}
+		ret[g.index] = errors.Aggregated(k.pattern, k.in, k.code, len(g.errors), g.errors[0], samplePaths)
+	}
+	return ret
+}
+
+// pathPattern replaces array indices in a dotted path by "*", e.g. items.3.name becomes items.*.name
+func pathPattern(path string) string {
+	parts := strings.Split(path, ".")
+	for i, p := range parts {
+		if _, err := strconv.Atoi(p); err == nil {
+			parts[i] = "*"
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// FieldErrorType is the kind of a FieldError. The values match the error types of
+// the k8s.io/apimachinery field package, so that they can be converted directly.
+type FieldErrorType string
+
+// Types of FieldError
+const (
+	FieldValueRequired     FieldErrorType = "FieldValueRequired"
+	FieldValueInvalid      FieldErrorType = "FieldValueInvalid"
+	FieldValueTypeInvalid  FieldErrorType = "FieldValueTypeInvalid"
+	FieldValueNotSupported FieldErrorType = "FieldValueNotSupported"
+	FieldValueDuplicate    FieldErrorType = "FieldValueDuplicate"
+	FieldValueForbidden    FieldErrorType = "FieldValueForbidden"
+	FieldValueTooLong      FieldErrorType = "FieldValueTooLong"
+	FieldValueTooMany      FieldErrorType = "FieldValueTooMany"
+	InternalError          FieldErrorType = "InternalError"
+)
+
+// FieldError is a validation error attached to a field of the validated value,
+// in the shape of the errors of the k8s.io/apimachinery field package.
+type FieldError struct {
+	Type     FieldErrorType
+	Field    string
+	BadValue interface{}
+	Detail   string
+}
+
+// Error implements the error interface
+func (f *FieldError) Error() string {
+	if f.Field == "" {
+		return f.Detail
+	}
+	return fmt.Sprintf("%s: %s", f.Field, f.Detail)
+}
+
+// AsFieldErrors renders the errors of this result as field errors.
+//
+// Field paths use the notation of the field package, e.g. spec.containers[0].name,
+// and are derived from the dotted paths or JSON pointers of the validation errors.
+// Errors which are not validation errors are reported as InternalError without field.
+func (r *Result) AsFieldErrors() []FieldError {
+	if r == nil || len(r.Errors) == 0 {
+		return nil
+	}
+	ret := make([]FieldError, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		ret = append(ret, asFieldErrors(e)...)
+	}
+	return ret
+}
+
+func asFieldErrors(e error) []FieldError {
+	switch err := e.(type) {
+	case *errors.CompositeError:
+		var ret []FieldError
+		for _, e := range err.Errors {
+			ret = append(ret, asFieldErrors(e)...)
+		}
+		return ret
+	case *errors.Validation:
+		field := err.Name
+		badValue := err.Value
+		var errType FieldErrorType
+		switch err.Code() {
+		case errors.RequiredFailCode:
+			errType = FieldValueRequired
+		case errors.InvalidTypeCode:
+			errType = FieldValueTypeInvalid
+		case errors.EnumFailCode:
+			errType = FieldValueNotSupported
+		case errors.UniqueFailCode:
+			errType = FieldValueDuplicate
+		case errors.TooLongFailCode:
+			errType = FieldValueTooLong
+		case errors.MaxItemsFailCode, errors.TooManyPropertiesCode:
+			errType = FieldValueTooMany
+		case errors.InvalidRuleCode, errors.MultipleOfMustBePositiveCode:
+			// the schema is invalid, not the value
+			errType = InternalError
+		case errors.UnallowedPropertyCode:
+			// the name is the path of the object, the value the name of the property
+			errType = FieldValueForbidden
+			field = fmt.Sprintf("%s.%v", field, err.Value)
+			badValue = nil
+		default:
+			errType = FieldValueInvalid
+		}
+		if err.InvalidSchema {
+			// e.g. a pattern which cannot be compiled
+			errType = InternalError
+		}
+		return []FieldError{{
+			Type:     errType,
+			Field:    fieldPath(field),
+			BadValue: badValue,
+			Detail:   err.Error(),
+		}}
+	default:
+		return []FieldError{{Type: InternalError, Detail: e.Error()}}
+	}
+}
+
+// fieldPath converts a dotted path (e.g. .spec.containers.0.name) or a JSON pointer
+// (e.g. /spec/containers/0/name) into a field path (e.g. spec.containers[0].name).
+func fieldPath(path string) string {
+	var parts []string
+	if strings.HasPrefix(path, "/") {
+		parts = strings.Split(path[1:], "/")
+		for i, p := range parts {
+			parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(p)
+		}
+	} else {
+		parts = strings.Split(strings.TrimPrefix(path, "."), ".")
+	}
+
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(p); err == nil || p == "*" {
+			b.WriteString("[" + p + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
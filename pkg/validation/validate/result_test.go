@@ -19,6 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // Test AddError() uniqueness
@@ -211,3 +214,96 @@ func TestResult_NilInstance(t *testing.T) {
 	assert.False(t, r.HasWarnings())
 	assert.False(t, r.HasErrorsOrWarnings())
 }
+
+func TestResult_AsFieldErrors(t *testing.T) {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {
+					SchemaProps: spec.SchemaProps{
+						Type:     []string{"object"},
+						Required: []string{"name"},
+						Properties: map[string]spec.Schema{
+							"name": *spec.StringProperty(),
+							"ports": *spec.ArrayProperty(&spec.Schema{
+								SchemaProps: spec.SchemaProps{
+									Type: []string{"object"},
+									Properties: map[string]spec.Schema{
+										"port": *spec.Int64Property(),
+									},
+								},
+							}),
+						},
+					},
+				},
+			},
+		},
+	}
+	data := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"port": int64(80)},
+				map[string]interface{}{"port": "http"},
+			},
+		},
+	}
+
+	res := NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(data)
+	fieldErrors := res.AsFieldErrors()
+	if assert.Len(t, fieldErrors, 2) {
+		assert.Contains(t, fieldErrors, FieldError{
+			Type:   FieldValueRequired,
+			Field:  "spec.name",
+			Detail: "spec.name in body is required",
+		})
+		assert.Contains(t, fieldErrors, FieldError{
+			Type:     FieldValueTypeInvalid,
+			Field:    "spec.ports[1].port",
+			BadValue: "string",
+			Detail:   "spec.ports.1.port in body must be of type integer: \"string\"",
+		})
+	}
+
+	assert.Nil(t, (&Result{}).AsFieldErrors())
+	assert.Equal(t, []FieldError{{Type: InternalError, Detail: "oops"}}, (&Result{Errors: []error{fmt.Errorf("oops")}}).AsFieldErrors())
+}
+
+func TestResult_SchemaAndDataErrors(t *testing.T) {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"name":  {SchemaProps: spec.SchemaProps{Type: []string{"string"}, Pattern: "^[a-z"}},
+				"count": *spec.Int64Property(),
+			},
+		},
+	}
+
+	res := NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(map[string]interface{}{
+		"name":  "abc",
+		"count": "many",
+	})
+	if assert.Len(t, res.SchemaErrors(), 1) {
+		assert.Contains(t, res.SchemaErrors()[0].Error(), "pattern is invalid")
+		assert.Equal(t, SchemaError, ClassifyError(res.SchemaErrors()[0]))
+	}
+	if assert.Len(t, res.DataErrors(), 1) {
+		assert.Contains(t, res.DataErrors()[0].Error(), "count in body must be of type integer")
+		assert.Equal(t, DataError, ClassifyError(res.DataErrors()[0]))
+	}
+
+	// unresolvable references are schema errors
+	res = NewSchemaValidator(spec.RefSchema("#/definitions/Missing"), nil, "", strfmt.Default).Validate("abc")
+	assert.Len(t, res.SchemaErrors(), 1)
+	assert.Empty(t, res.DataErrors())
+}
+
+func TestFieldPath(t *testing.T) {
+	assert.Equal(t, "spec.containers[0].name", fieldPath(".spec.containers.0.name"))
+	assert.Equal(t, "spec.containers[0].name", fieldPath("spec.containers.0.name"))
+	assert.Equal(t, "spec.containers[0].name", fieldPath("/spec/containers/0/name"))
+	assert.Equal(t, "metadata.annotations.a/b", fieldPath("/metadata/annotations/a~1b"))
+	assert.Equal(t, "items[*].name", fieldPath("items.*.name"))
+	assert.Equal(t, "", fieldPath(""))
+}
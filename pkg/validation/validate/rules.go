@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// extensionValidations is the extension holding the validation rules of a schema.
+const extensionValidations = "x-kubernetes-validations"
+
+// ValidationRule is an entry of the x-kubernetes-validations extension of a schema.
+type ValidationRule struct {
+	// Rule is the expression to evaluate, e.g. a CEL expression like "self.minReplicas <= self.maxReplicas".
+	Rule string `json:"rule"`
+	// Message is reported when the rule is not satisfied. The rule itself is reported if empty.
+	Message string `json:"message,omitempty"`
+}
+
+// RuleProgram evaluates a compiled rule against the value validated by the schema holding the rule.
+// It returns false if the value does not satisfy the rule.
+type RuleProgram func(self interface{}) (bool, error)
+
+// RuleCompiler compiles the rules of the x-kubernetes-validations extension, e.g. with CEL.
+type RuleCompiler func(rule string) (RuleProgram, error)
+
+// ruleCache compiles each rule once, and records which compilation errors have already been reported.
+type ruleCache struct {
+	compile RuleCompiler
+
+	lock     sync.Mutex
+	programs map[string]*compiledRule
+}
+
+type compiledRule struct {
+	program  RuleProgram
+	err      error
+	reported bool
+}
+
+func newRuleCache(compile RuleCompiler) *ruleCache {
+	return &ruleCache{
+		compile:  compile,
+		programs: map[string]*compiledRule{},
+	}
+}
+
+// get returns the program of the rule. On compilation error, report is only true the first time.
+func (c *ruleCache) get(rule string) (program RuleProgram, err error, report bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	compiled, ok := c.programs[rule]
+	if !ok {
+		compiled = &compiledRule{}
+		compiled.program, compiled.err = c.compile(rule)
+		c.programs[rule] = compiled
+	}
+	if compiled.err != nil {
+		report = !compiled.reported
+		compiled.reported = true
+	}
+	return compiled.program, compiled.err, report
+}
+
+// schemaValidationRules returns the rules of the x-kubernetes-validations extension of the schema.
+func schemaValidationRules(schema *spec.Schema) ([]ValidationRule, error) {
+	ext, ok := schema.Extensions[extensionValidations]
+	if !ok || ext == nil {
+		return nil, nil
+	}
+	if rules, ok := ext.([]ValidationRule); ok {
+		return rules, nil
+	}
+	b, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	var rules []ValidationRule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("invalid %s extension: %v", extensionValidations, err)
+	}
+	return rules, nil
+}
+
+type ruleValidator struct {
+	Path  string
+	In    string
+	Rules []ValidationRule
+	// Err is the error met while reading the rules of the schema
+	Err   error
+	cache *ruleCache
+}
+
+func (r *ruleValidator) SetPath(path string) {
+	r.Path = path
+}
+
+func (r *ruleValidator) Applies(source interface{}, kind reflect.Kind) bool {
+	if _, ok := source.(*spec.Schema); !ok {
+		return false
+	}
+	return r.cache != nil && (len(r.Rules) > 0 || r.Err != nil)
+}
+
+func (r *ruleValidator) Validate(data interface{}) *Result {
+	result := new(Result)
+	if r.Err != nil {
+		result.AddErrors(errors.InvalidRule(r.Path, r.In, extensionValidations, r.Err))
+		return result
+	}
+	for _, rule := range r.Rules {
+		program, err, report := r.cache.get(rule.Rule)
+		if err != nil {
+			if report {
+				result.AddErrors(errors.InvalidRule(r.Path, r.In, rule.Rule, err))
+			}
+			continue
+		}
+		ok, err := program(data)
+		if err != nil {
+			result.AddErrors(errors.FailedRule(r.Path, r.In, fmt.Sprintf("%s: %v", rule.Rule, err), data))
+			continue
+		}
+		if !ok {
+			message := rule.Message
+			if message == "" {
+				message = rule.Rule
+			}
+			result.AddErrors(errors.FailedRule(r.Path, r.In, message, data))
+		}
+	}
+	return result
+}
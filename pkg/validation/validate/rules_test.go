@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+)
+
+// testRuleCompiler compiles a handful of rules, standing in for a CEL compiler.
+func testRuleCompiler(compiled map[string]int) RuleCompiler {
+	return func(rule string) (RuleProgram, error) {
+		compiled[rule]++
+		switch rule {
+		case "self.min <= self.max":
+			return func(self interface{}) (bool, error) {
+				obj := self.(map[string]interface{})
+				return obj["min"].(float64) <= obj["max"].(float64), nil
+			}, nil
+		case "self.size() > 0":
+			return func(self interface{}) (bool, error) {
+				return len(self.(string)) > 0, nil
+			}, nil
+		}
+		return nil, fmt.Errorf("syntax error in %q", rule)
+	}
+}
+
+func TestSchemaValidator_ValidationRules(t *testing.T) {
+	var schemaJSON = `
+{
+    "type": "array",
+    "items": {
+        "type": "object",
+        "properties": {
+            "min": {"type": "number"},
+            "max": {"type": "number"},
+            "name": {
+                "type": "string",
+                "x-kubernetes-validations": [{"rule": "self.size() > 0"}, {"rule": "self >"}]
+            }
+        },
+        "x-kubernetes-validations": [{"rule": "self.min <= self.max", "message": "min must not be greater than max"}]
+    }
+}`
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	var input []interface{}
+
+	// without compiler, rules are ignored
+	require.NoError(t, json.Unmarshal([]byte(`[{"min": 2, "max": 1}]`), &input))
+	assert.NoError(t, AgainstSchema(schema, input, strfmt.Default))
+
+	compiled := map[string]int{}
+	require.NoError(t, json.Unmarshal([]byte(`[{"min": 1, "max": 2}, {"min": 3, "max": 4}]`), &input))
+	err := AgainstSchema(schema, input, strfmt.Default, WithRuleCompiler(testRuleCompiler(compiled)))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"self.min <= self.max": 1}, compiled)
+
+	compiled = map[string]int{}
+	require.NoError(t, json.Unmarshal([]byte(`[{"min": 1, "max": 2, "name": "a"}, {"min": 2, "max": 1, "name": ""}, {"min": 1, "max": 1, "name": "b"}]`), &input))
+	err = AgainstSchema(schema, input, strfmt.Default, WithRuleCompiler(testRuleCompiler(compiled)))
+	require.Error(t, err)
+	errs := err.(*errors.CompositeError).Errors
+
+	var failed, invalid []string
+	for _, e := range errs {
+		switch e.(*errors.Validation).Code() {
+		case errors.FailedRuleCode:
+			failed = append(failed, e.Error())
+		case errors.InvalidRuleCode:
+			invalid = append(invalid, e.Error())
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		".1 in body failed rule: min must not be greater than max",
+		".1.name in body failed rule: self.size() > 0",
+	}, failed)
+	// compilation errors are reported once
+	assert.Equal(t, []string{`.0.name in body has an invalid rule "self >": syntax error in "self >"`}, invalid)
+	assert.Equal(t, map[string]int{"self.min <= self.max": 1, "self.size() > 0": 1, "self >": 1}, compiled)
+}
@@ -16,7 +16,6 @@ package validate
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/go-openapi/swag"
@@ -39,22 +38,41 @@ type SchemaValidator struct {
 	Root         interface{}
 	KnownFormats strfmt.Registry
 	Options      SchemaValidatorOptions
+
+	// refErr is set when the $ref of the schema could not be resolved
+	refErr error
 }
 
 // AgainstSchema validates the specified data against the provided schema, using a registry of supported formats.
 //
 // When no pre-parsed *spec.Schema structure is provided, it uses a JSON schema as default. See example.
 func AgainstSchema(schema *spec.Schema, data interface{}, formats strfmt.Registry, options ...Option) error {
-	res := NewSchemaValidator(schema, nil, "", formats, options...).Validate(data)
+	validator := NewSchemaValidator(schema, nil, "", formats, options...)
+	res := validator.Validate(data)
 	if res.HasErrors() {
+		if validator.Options.EnableErrorAggregation {
+			return errors.CompositeValidationError(aggregateErrors(res.Errors)...)
+		}
 		return errors.CompositeValidationError(res.Errors...)
 	}
 	return nil
 }
 
+// AgainstSchemaWithRefResolver validates the specified data against the provided schema, using a registry
+// of supported formats and a resolver for the $refs pointing outside of the schema.
+//
+// Local $refs (e.g. "#/definitions/Foo") are resolved against the schema, or against the schema
+// returned by the resolver when they appear in a resolved schema.
+func AgainstSchemaWithRefResolver(schema *spec.Schema, data interface{}, formats strfmt.Registry, resolver RefResolver, options ...Option) error {
+	return AgainstSchema(schema, data, formats, append([]Option{WithRefResolver(resolver)}, options...)...)
+}
+
 // NewSchemaValidator creates a new schema validator.
 //
-// Panics if the provided schema is invalid.
+// Local $refs are resolved against rootSchema, other $refs with the RefResolver of the options.
+//
+// Panics if the provided schema is invalid. Non-local $refs which cannot be resolved because no
+// RefResolver is set are reported as validation errors.
 func NewSchemaValidator(schema *spec.Schema, rootSchema interface{}, root string, formats strfmt.Registry, options ...Option) *SchemaValidator {
 	if schema == nil {
 		return nil
@@ -64,10 +82,6 @@ func NewSchemaValidator(schema *spec.Schema, rootSchema interface{}, root string
 		rootSchema = schema
 	}
 
-	if ref := schema.Ref.String(); ref != "" {
-		panic(fmt.Sprintf("schema references not supported: %s", ref))
-	}
-
 	s := SchemaValidator{
 		Path:         root,
 		in:           "body",
@@ -78,6 +92,23 @@ func NewSchemaValidator(schema *spec.Schema, rootSchema interface{}, root string
 	for _, o := range options {
 		o(&s.Options)
 	}
+
+	if s.Options.schemaPath == "" {
+		s.Options.schemaPath = "#"
+	}
+
+	if hasSchemaRef(schema) {
+		resolved, err := resolveSchemaRef(schema, rootSchema, root, &s.Options)
+		if err != nil {
+			s.refErr = err
+			return &s
+		}
+		s.Schema = resolved.schema
+		s.Root = resolved.root
+		s.Options.refChain = resolved.chain
+		s.Options.schemaPath = resolved.schemaPath
+	}
+
 	s.validators = []valueValidator{
 		s.typeValidator(),
 		s.schemaPropsValidator(),
@@ -87,13 +118,19 @@ func NewSchemaValidator(schema *spec.Schema, rootSchema interface{}, root string
 		s.sliceValidator(),
 		s.commonValidator(),
 		s.objectValidator(),
+		s.ruleValidator(),
+		s.numericStringValidator(),
+		s.keywordValidator(),
 	}
 	return &s
 }
 
-// SetPath sets the path for this schema valdiator
+// SetPath sets the path for this schema valdiator, and for its nested validators
 func (s *SchemaValidator) SetPath(path string) {
 	s.Path = path
+	for _, v := range s.validators {
+		v.SetPath(path)
+	}
 }
 
 // Applies returns true when this schema validator applies
@@ -109,6 +146,14 @@ func (s *SchemaValidator) Validate(data interface{}) *Result {
 		return result
 	}
 
+	if s.refErr != nil {
+		result.AddErrors(s.refErr)
+		return result
+	}
+
+	// errors of nested validators already have their schema path
+	defer s.setSchemaPath(result)
+
 	if data == nil {
 		result.Merge(s.validators[0].Validate(data)) // type validator
 		result.Merge(s.validators[6].Validate(data)) // common validator
@@ -166,20 +211,33 @@ func (s *SchemaValidator) Validate(data interface{}) *Result {
 		err := v.Validate(d)
 		result.Merge(err)
 		result.Inc()
+		if s.Options.errorLimitReached(result) {
+			break
+		}
 	}
 	result.Inc()
 	return result
 }
 
+// setSchemaPath sets the schema path of the validation errors of result which do not have one yet.
+func (s *SchemaValidator) setSchemaPath(result *Result) {
+	for _, e := range result.Errors {
+		if v, ok := e.(*errors.Validation); ok && v.SchemaPath == "" {
+			v.SchemaPath = s.Options.schemaPath
+		}
+	}
+}
+
 func (s *SchemaValidator) typeValidator() valueValidator {
 	return &typeValidator{Type: s.Schema.Type, Nullable: s.Schema.Nullable, Format: s.Schema.Format, In: s.in, Path: s.Path}
 }
 
 func (s *SchemaValidator) commonValidator() valueValidator {
 	return &basicCommonValidator{
-		Path: s.Path,
-		In:   s.in,
-		Enum: s.Schema.Enum,
+		Path:            s.Path,
+		In:              s.in,
+		Enum:            s.Schema.Enum,
+		CaseInsensitive: s.Options.CaseInsensitiveEnums,
 	}
 }
 
@@ -213,11 +271,46 @@ func (s *SchemaValidator) numberValidator() valueValidator {
 
 func (s *SchemaValidator) stringValidator() valueValidator {
 	return &stringValidator{
-		Path:      s.Path,
-		In:        s.in,
-		MaxLength: s.Schema.MaxLength,
-		MinLength: s.Schema.MinLength,
-		Pattern:   s.Schema.Pattern,
+		Path:          s.Path,
+		In:            s.in,
+		MaxLength:     s.Schema.MaxLength,
+		MinLength:     s.Schema.MinLength,
+		Pattern:       s.Schema.Pattern,
+		DecodedLength: s.Options.DecodedByteLength && s.Schema.Format == stringFormatByte,
+	}
+}
+
+func (s *SchemaValidator) keywordValidator() valueValidator {
+	return &keywordValidator{
+		Schema:   s.Schema,
+		Keywords: s.Options.Keywords,
+	}
+}
+
+func (s *SchemaValidator) numericStringValidator() valueValidator {
+	return &numericStringValidator{
+		Path:             s.Path,
+		In:               s.in,
+		Enabled:          s.Options.NumericStrings && s.Schema.Type.Contains(stringType),
+		Format:           s.Schema.Format,
+		Maximum:          s.Schema.Maximum,
+		ExclusiveMaximum: s.Schema.ExclusiveMaximum,
+		Minimum:          s.Schema.Minimum,
+		ExclusiveMinimum: s.Schema.ExclusiveMinimum,
+	}
+}
+
+func (s *SchemaValidator) ruleValidator() valueValidator {
+	if s.Options.rules == nil {
+		return &ruleValidator{}
+	}
+	rules, err := schemaValidationRules(s.Schema)
+	return &ruleValidator{
+		Path:  s.Path,
+		In:    s.in,
+		Rules: rules,
+		Err:   err,
+		cache: s.Options.rules,
 	}
 }
 
@@ -232,7 +325,9 @@ func (s *SchemaValidator) formatValidator() valueValidator {
 
 func (s *SchemaValidator) schemaPropsValidator() valueValidator {
 	sch := s.Schema
-	return newSchemaPropsValidator(s.Path, s.in, sch.AllOf, sch.OneOf, sch.AnyOf, sch.Not, sch.Dependencies, s.Root, s.KnownFormats, s.Options.Options()...)
+	v := newSchemaPropsValidator(s.Path, s.in, sch.AllOf, sch.OneOf, sch.AnyOf, sch.Not, sch.Dependencies, s.Root, s.KnownFormats, s.Options.Options()...)
+	v.setDiscriminator(sch.Discriminator)
+	return v
 }
 
 func (s *SchemaValidator) objectValidator() valueValidator {
@@ -37,6 +37,12 @@ const (
 	// MustValidateOnlyOneSchemaError indicates that in a OneOf construct, either none of the schema constraints specified were verified, or several were
 	MustValidateOnlyOneSchemaError = "%q must validate one and only one schema (oneOf). %s"
 
+	// MissingDiscriminatorError indicates that a value validated by a oneOf or anyOf construct with a discriminator does not have the discriminator property
+	MissingDiscriminatorError = "%q must have the discriminator property %s (%s)"
+
+	// UnmappedDiscriminatorError indicates that the discriminator value of a value validated by a oneOf or anyOf construct does not select any of its schemas
+	UnmappedDiscriminatorError = "%q has no schema for the discriminator value %s=%v (%s)"
+
 	// MustValidateAllSchemasError indicates that in a AllOf construct, at least one of the schema constraints specified were not verified
 	//
 	// TODO: punctuation in message
@@ -44,6 +50,9 @@ const (
 
 	// MustNotValidateSchemaError indicates that in a Not construct, the schema constraint specified was verified
 	MustNotValidateSchemaError = "%q must not validate the schema (not)"
+
+	// UnresolvableSchemaRefError indicates that a schema has a non-local $ref while no RefResolver is set
+	UnresolvableSchemaRefError = "schema reference %s in %q cannot be resolved: no reference resolver is set"
 )
 
 // Warning messages related to schema validation and returned as results
@@ -55,6 +64,12 @@ func invalidTypeConversionMsg(path string, err error) errors.Error {
 func mustValidateOnlyOneSchemaMsg(path, additionalMsg string) errors.Error {
 	return errors.New(errors.CompositeErrorCode, MustValidateOnlyOneSchemaError, path, additionalMsg)
 }
+func missingDiscriminatorMsg(path, discriminator, keyword string) errors.Error {
+	return errors.New(errors.CompositeErrorCode, MissingDiscriminatorError, path, discriminator, keyword)
+}
+func unmappedDiscriminatorMsg(path, discriminator string, value interface{}, keyword string) errors.Error {
+	return errors.New(errors.CompositeErrorCode, UnmappedDiscriminatorError, path, discriminator, value, keyword)
+}
 func mustValidateAtLeastOneSchemaMsg(path string) errors.Error {
 	return errors.New(errors.CompositeErrorCode, MustValidateAtLeastOneSchemaError, path)
 }
@@ -70,3 +85,7 @@ func hasADependencyMsg(path, depkey string) errors.Error {
 func arrayDoesNotAllowAdditionalItemsMsg() errors.Error {
 	return errors.New(errors.CompositeErrorCode, ArrayDoesNotAllowAdditionalItemsError)
 }
+
+func unresolvableSchemaRefMsg(path, ref string) errors.Error {
+	return errors.New(errors.CompositeErrorCode, UnresolvableSchemaRefError, ref, path)
+}
@@ -14,14 +14,197 @@
 
 package validate
 
+import "strings"
+
 // SchemaValidatorOptions defines optional rules for schema validation
 type SchemaValidatorOptions struct {
+	// EnableErrorAggregation collapses the errors returned by AgainstSchema which share the
+	// same code and path pattern (e.g. "items.*.name") into a single error reporting their count.
+	EnableErrorAggregation bool
+
+	// RefResolver resolves the $refs which do not point inside the root schema.
+	RefResolver RefResolver
+
+	// MaxErrors stops the validation once more errors have been found, to bound the work done on
+	// adversarial inputs. The errors in excess are dropped and the result is then marked as
+	// truncated. 0 means unlimited.
+	MaxErrors int
+
+	// NumericStrings validates the string values of string schemas with a numeric format (e.g. "int32",
+	// "double" or "number") as numbers encoded as strings: they must parse as a number of that format,
+	// within the minimum and maximum of the schema.
+	NumericStrings bool
+
+	// CaseInsensitiveEnums matches string values against the string values of enums ignoring case.
+	// Values matching an enum value only when ignoring case are valid, with a warning reporting the
+	// enum value, i.e. the canonical spelling.
+	CaseInsensitiveEnums bool
+
+	// DecodedByteLength applies the minLength and maxLength of the schemas of format "byte" to the number
+	// of bytes encoded in base64 by the values rather than to the length of the values.
+	DecodedByteLength bool
+
+	// DefaultEmptyValues makes ApplyDefaults replace the empty values of properties with a default, i.e.
+	// null, "", 0, false, [] and {}, rather than only setting the properties which are absent.
+	DefaultEmptyValues bool
+
+	// NormalizeDateTimes makes AgainstSchemaWithCoercion convert the values of the schemas of format
+	// "date-time" to UTC, in RFC3339 form.
+	NormalizeDateTimes bool
+
+	// Keywords holds the validators of custom keywords, see WithKeywords.
+	Keywords Keywords
+
+	// rules evaluates the x-kubernetes-validations rules, if set with WithRuleCompiler.
+	rules *ruleCache
+
+	// refChain holds the $refs followed at the paths of the schema being validated, to detect cycles.
+	refChain []string
+
+	// schemaPath locates the schema being validated, see errors.Validation.SchemaPath.
+	schemaPath string
 }
 
 // Option sets optional rules for schema validation
 type Option func(*SchemaValidatorOptions)
 
+// EnableErrorAggregation activates the aggregation of similar errors. Disabled by default.
+func EnableErrorAggregation(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.EnableErrorAggregation = enable
+	}
+}
+
+// WithRefResolver sets the resolver used for $refs which do not point inside the root schema.
+func WithRefResolver(resolver RefResolver) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.RefResolver = resolver
+	}
+}
+
+// WithRuleCompiler enables the evaluation of the x-kubernetes-validations rules of the schemas with
+// the programs returned by compiler. Each rule is compiled once per validation, and compilation
+// errors are reported once.
+func WithRuleCompiler(compiler RuleCompiler) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.rules = nil
+		if compiler != nil {
+			svo.rules = newRuleCache(compiler)
+		}
+	}
+}
+
+// WithMaxErrors stops the validation after max errors. 0, the default, means unlimited.
+func WithMaxErrors(max int) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.MaxErrors = max
+	}
+}
+
+// WithNumericStrings enables the validation of numeric strings, see SchemaValidatorOptions.NumericStrings.
+// Disabled by default.
+func WithNumericStrings(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.NumericStrings = enable
+	}
+}
+
+// WithCaseInsensitiveEnums enables matching enums ignoring case, see SchemaValidatorOptions.CaseInsensitiveEnums.
+// Disabled by default.
+func WithCaseInsensitiveEnums(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.CaseInsensitiveEnums = enable
+	}
+}
+
+// WithDecodedByteLength enables checking the length of the decoded values of the "byte" format, see
+// SchemaValidatorOptions.DecodedByteLength. Disabled by default.
+func WithDecodedByteLength(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.DecodedByteLength = enable
+	}
+}
+
+// WithDefaultEmptyValues makes ApplyDefaults default empty values, see SchemaValidatorOptions.DefaultEmptyValues.
+// Disabled by default: as in JSON schema, defaults only apply to absent properties.
+func WithDefaultEmptyValues(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.DefaultEmptyValues = enable
+	}
+}
+
+// WithNormalizedDateTimes enables the normalization of date-times, see SchemaValidatorOptions.NormalizeDateTimes.
+// Disabled by default.
+func WithNormalizedDateTimes(enable bool) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.NormalizeDateTimes = enable
+	}
+}
+
+// WithKeywords enables the validation of the custom keywords registered in keywords.
+// The built-in keywords are validated as usual.
+func WithKeywords(keywords Keywords) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.Keywords = keywords
+	}
+}
+
+func withRuleCache(rules *ruleCache) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.rules = rules
+	}
+}
+
+func withRefChain(chain []string) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.refChain = chain
+	}
+}
+
+func withSchemaPath(path string) Option {
+	return func(svo *SchemaValidatorOptions) {
+		svo.schemaPath = path
+	}
+}
+
 // Options returns current options
 func (svo SchemaValidatorOptions) Options() []Option {
-	return []Option{}
+	return []Option{
+		EnableErrorAggregation(svo.EnableErrorAggregation),
+		WithRefResolver(svo.RefResolver),
+		WithMaxErrors(svo.MaxErrors),
+		WithNumericStrings(svo.NumericStrings),
+		WithCaseInsensitiveEnums(svo.CaseInsensitiveEnums),
+		WithDecodedByteLength(svo.DecodedByteLength),
+		WithDefaultEmptyValues(svo.DefaultEmptyValues),
+		WithNormalizedDateTimes(svo.NormalizeDateTimes),
+		WithKeywords(svo.Keywords),
+		withRuleCache(svo.rules),
+		withRefChain(svo.refChain),
+		withSchemaPath(svo.schemaPath),
+	}
+}
+
+// errorLimitReached returns true if the result has exceeded the maximum number of errors, in which
+// case the errors in excess are dropped and the result is marked as truncated, or if it was truncated
+// by a nested validation. A result with exactly the maximum number of errors is not truncated.
+func (svo SchemaValidatorOptions) errorLimitReached(r *Result) bool {
+	if svo.MaxErrors <= 0 {
+		return false
+	}
+	if len(r.Errors) > svo.MaxErrors {
+		r.Errors = r.Errors[:svo.MaxErrors]
+		r.Truncated = true
+	}
+	return r.Truncated
+}
+
+// optionsAt returns the current options for the validation of the sub-schema found
+// under the given keywords, e.g. "properties", "name".
+func (svo SchemaValidatorOptions) optionsAt(keywords ...string) []Option {
+	path := svo.schemaPath
+	for _, k := range keywords {
+		path += "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+	}
+	return append(svo.Options(), withSchemaPath(path))
 }
@@ -17,6 +17,8 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"k8s.io/kube-openapi/pkg/validation/spec"
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
@@ -37,37 +39,52 @@ type schemaPropsValidator struct {
 	Root            interface{}
 	KnownFormats    strfmt.Registry
 	Options         SchemaValidatorOptions
+
+	// Discriminator is the name of the property selecting the oneOf (or else anyOf) schema to validate against, if any.
+	Discriminator string
+	// discriminatorKeyword is the keyword of the schemas selected by the discriminator, i.e. "oneOf" or "anyOf".
+	discriminatorKeyword string
+	// discriminatorMapping maps the values of the discriminator property to the index of their schema.
+	discriminatorMapping map[string]int
 }
 
 func (s *schemaPropsValidator) SetPath(path string) {
 	s.Path = path
+	for _, validators := range [][]SchemaValidator{s.anyOfValidators, s.allOfValidators, s.oneOfValidators} {
+		for i := range validators {
+			validators[i].SetPath(path)
+		}
+	}
+	if s.notValidator != nil {
+		s.notValidator.SetPath(path)
+	}
 }
 
 func newSchemaPropsValidator(path string, in string, allOf, oneOf, anyOf []spec.Schema, not *spec.Schema, deps spec.Dependencies, root interface{}, formats strfmt.Registry, options ...Option) *schemaPropsValidator {
+	schOptions := &SchemaValidatorOptions{}
+	for _, o := range options {
+		o(schOptions)
+	}
+
 	var anyValidators []SchemaValidator
-	for _, v := range anyOf {
+	for i, v := range anyOf {
 		v := v
-		anyValidators = append(anyValidators, *NewSchemaValidator(&v, root, path, formats, options...))
+		anyValidators = append(anyValidators, *NewSchemaValidator(&v, root, path, formats, schOptions.optionsAt("anyOf", strconv.Itoa(i))...))
 	}
 	var allValidators []SchemaValidator
-	for _, v := range allOf {
+	for i, v := range allOf {
 		v := v
-		allValidators = append(allValidators, *NewSchemaValidator(&v, root, path, formats, options...))
+		allValidators = append(allValidators, *NewSchemaValidator(&v, root, path, formats, schOptions.optionsAt("allOf", strconv.Itoa(i))...))
 	}
 	var oneValidators []SchemaValidator
-	for _, v := range oneOf {
+	for i, v := range oneOf {
 		v := v
-		oneValidators = append(oneValidators, *NewSchemaValidator(&v, root, path, formats, options...))
+		oneValidators = append(oneValidators, *NewSchemaValidator(&v, root, path, formats, schOptions.optionsAt("oneOf", strconv.Itoa(i))...))
 	}
 
 	var notValidator *SchemaValidator
 	if not != nil {
-		notValidator = NewSchemaValidator(not, root, path, formats, options...)
-	}
-
-	schOptions := &SchemaValidatorOptions{}
-	for _, o := range options {
-		o(schOptions)
+		notValidator = NewSchemaValidator(not, root, path, formats, schOptions.optionsAt("not")...)
 	}
 	return &schemaPropsValidator{
 		Path:            path,
@@ -87,6 +104,63 @@ func newSchemaPropsValidator(path string, in string, allOf, oneOf, anyOf []spec.
 	}
 }
 
+// setDiscriminator makes the oneOf construct, or else the anyOf construct, select the schema to validate
+// against by the value of the discriminator property, instead of trying all the schemas. A schema is
+// selected by a value if it is a $ref to a definition named after the value, or if the discriminator
+// property of the schema is an enum holding the value.
+func (s *schemaPropsValidator) setDiscriminator(discriminator string) {
+	s.Discriminator = discriminator
+	if discriminator == "" {
+		return
+	}
+	schemas, validators := s.OneOf, s.oneOfValidators
+	s.discriminatorKeyword = "oneOf"
+	if len(schemas) == 0 {
+		schemas, validators = s.AnyOf, s.anyOfValidators
+		s.discriminatorKeyword = "anyOf"
+	}
+	s.discriminatorMapping = map[string]int{}
+	for i := range schemas {
+		if ref := schemas[i].Ref.String(); ref != "" {
+			s.discriminatorMapping[ref[strings.LastIndex(ref, "/")+1:]] = i
+		}
+	}
+	for i, v := range validators {
+		for _, value := range v.Schema.Properties[discriminator].Enum {
+			if str, ok := value.(string); ok {
+				s.discriminatorMapping[str] = i
+			}
+		}
+	}
+}
+
+// discriminates returns true if the schema to validate data against is selected by its discriminator
+// value among the schemas of keyword.
+func (s *schemaPropsValidator) discriminates(keyword string, data interface{}) bool {
+	_, ok := data.(map[string]interface{})
+	return ok && s.Discriminator != "" && s.discriminatorKeyword == keyword
+}
+
+// validateDiscriminated validates data against the oneOf or anyOf schema selected by its discriminator value.
+func (s *schemaPropsValidator) validateDiscriminated(data map[string]interface{}) *Result {
+	result := new(Result)
+	value, ok := data[s.Discriminator]
+	if !ok {
+		result.AddErrors(missingDiscriminatorMsg(s.Path, s.Discriminator, s.discriminatorKeyword))
+		return result
+	}
+	str, _ := value.(string)
+	i, ok := s.discriminatorMapping[str]
+	if !ok {
+		result.AddErrors(unmappedDiscriminatorMsg(s.Path, s.Discriminator, value, s.discriminatorKeyword))
+		return result
+	}
+	if s.discriminatorKeyword == "anyOf" {
+		return s.anyOfValidators[i].Validate(data)
+	}
+	return s.oneOfValidators[i].Validate(data)
+}
+
 func (s *schemaPropsValidator) Applies(source interface{}, kind reflect.Kind) bool {
 	r := reflect.TypeOf(source) == specSchemaType
 	debugLog("schema props validator for %q applies %t for %T (kind: %v)\n", s.Path, r, source, kind)
@@ -105,7 +179,9 @@ func (s *schemaPropsValidator) Validate(data interface{}) *Result {
 
 	// Validates at least one in anyOf schemas
 	var firstSuccess *Result
-	if len(s.anyOfValidators) > 0 {
+	if s.discriminates("anyOf", data) {
+		mainResult.Merge(s.validateDiscriminated(data.(map[string]interface{})))
+	} else if len(s.anyOfValidators) > 0 {
 		var bestFailures *Result
 		succeededOnce := false
 		for _, anyOfSchema := range s.anyOfValidators {
@@ -138,7 +214,9 @@ func (s *schemaPropsValidator) Validate(data interface{}) *Result {
 	}
 
 	// Validates exactly one in oneOf schemas
-	if len(s.oneOfValidators) > 0 {
+	if s.discriminates("oneOf", data) {
+		mainResult.Merge(s.validateDiscriminated(data.(map[string]interface{})))
+	} else if len(s.oneOfValidators) > 0 {
 		var bestFailures *Result
 		var firstSuccess *Result
 		validated := 0
@@ -218,7 +296,7 @@ func (s *schemaPropsValidator) Validate(data interface{}) *Result {
 			if dep, ok := s.Dependencies[key]; ok {
 
 				if dep.Schema != nil {
-					mainResult.Merge(NewSchemaValidator(dep.Schema, s.Root, s.Path+"."+key, s.KnownFormats, s.Options.Options()...).Validate(data))
+					mainResult.Merge(NewSchemaValidator(dep.Schema, s.Root, s.Path+"."+key, s.KnownFormats, s.Options.optionsAt("dependencies", key)...).Validate(data))
 					continue
 				}
 
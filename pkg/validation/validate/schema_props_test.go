@@ -15,9 +15,13 @@
 package validate
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // Test edge cases in schema_props_validator which are difficult
@@ -28,3 +32,117 @@ func TestSchemaPropsValidator_EdgeCases(t *testing.T) {
 	s.SetPath("path")
 	assert.Equal(t, "path", s.Path)
 }
+
+func TestSchemaPropsValidator_Discriminator(t *testing.T) {
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"definitions": {
+			"Cat": {
+				"type": "object",
+				"required": ["lives"],
+				"properties": {"kind": {"type": "string"}, "lives": {"type": "integer"}}
+			}
+		},
+		"discriminator": "kind",
+		"oneOf": [
+			{"$ref": "#/definitions/Cat"},
+			{
+				"type": "object",
+				"required": ["breed"],
+				"properties": {"kind": {"type": "string", "enum": ["Dog", "Puppy"]}, "breed": {"type": "string"}}
+			}
+		]
+	}`), schema))
+
+	tests := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{name: "by reference", data: `{"kind": "Cat", "lives": 9}`},
+		{name: "by enum", data: `{"kind": "Puppy", "breed": "corgi"}`},
+		{
+			// would be valid against the Cat schema without the discriminator
+			name:          "failing the selected schema",
+			data:          `{"kind": "Dog", "lives": 9}`,
+			expectedError: "breed in body is required",
+		},
+		{
+			name:          "unmapped",
+			data:          `{"kind": "Bird", "lives": 1}`,
+			expectedError: `"" has no schema for the discriminator value kind=Bird (oneOf)`,
+		},
+		{
+			name:          "missing",
+			data:          `{"lives": 1}`,
+			expectedError: `"" must have the discriminator property kind (oneOf)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+			err := AgainstSchema(schema, data, strfmt.Default)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestSchemaPropsValidator_AnyOfDiscriminator(t *testing.T) {
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"discriminator": "type",
+		"anyOf": [
+			{
+				"type": "object",
+				"required": ["radius"],
+				"properties": {"type": {"type": "string", "enum": ["Circle"]}, "radius": {"type": "number"}}
+			},
+			{
+				"type": "object",
+				"required": ["side"],
+				"properties": {"type": {"type": "string", "enum": ["Square"]}, "side": {"type": "number"}}
+			}
+		]
+	}`), schema))
+
+	tests := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{name: "matching", data: `{"type": "Square", "side": 2}`},
+		{
+			// would be valid against the Circle schema without the discriminator
+			name:          "failing the selected schema",
+			data:          `{"type": "Square", "radius": 2}`,
+			expectedError: "side in body is required",
+		},
+		{
+			name:          "unknown",
+			data:          `{"type": "Triangle", "side": 2}`,
+			expectedError: `"" has no schema for the discriminator value type=Triangle (anyOf)`,
+		},
+		{
+			name:          "missing",
+			data:          `{"side": 2}`,
+			expectedError: `"" must have the discriminator property type (anyOf)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &data))
+			err := AgainstSchema(schema, data, strfmt.Default)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// RefResolver resolves a $ref which does not point inside the root schema being validated,
+// e.g. a reference to a schema stored in another file.
+type RefResolver func(ref string) (*spec.Schema, error)
+
+// dynamicRefKeywords are the keywords of the references resolved dynamically. Only references
+// to the root of the document, i.e. "#", are supported.
+var dynamicRefKeywords = []string{"$recursiveRef", "$dynamicRef"}
+
+// hasSchemaRef returns true if the schema has a $ref or one of the dynamicRefKeywords.
+func hasSchemaRef(schema *spec.Schema) bool {
+	if schema.Ref.String() != "" {
+		return true
+	}
+	_, _, ok := schemaDynamicRef(schema)
+	return ok
+}
+
+func schemaDynamicRef(schema *spec.Schema) (string, interface{}, bool) {
+	for _, keyword := range dynamicRefKeywords {
+		if ref, ok := schema.ExtraProps[keyword]; ok {
+			return keyword, ref, true
+		}
+	}
+	return "", nil, false
+}
+
+// resolvedSchema is a schema found by following references.
+type resolvedSchema struct {
+	schema *spec.Schema
+	// root is the root schema for the references of schema
+	root interface{}
+	// chain is the chain of references followed, see resolveSchemaRef
+	chain []string
+	// schemaPath is the location of schema, i.e. the last reference followed
+	schemaPath string
+}
+
+// resolveSchemaRef follows the $ref of schema until a schema without a $ref is found.
+//
+// Local references (fragment only, e.g. "#/definitions/Foo") are resolved against the root schema.
+// Other references are handed over to the resolver of the options; the schema returned by the
+// resolver becomes the root for the references it contains. $recursiveRef and $dynamicRef are
+// resolved to the root schema if they are "#", and are unsupported otherwise.
+//
+// The returned chain extends the chain of the options with the references followed at path. Following
+// twice the same reference at the same path means that no data has been consumed in between, i.e. a cycle.
+func resolveSchemaRef(schema *spec.Schema, root interface{}, path string, opts *SchemaValidatorOptions) (*resolvedSchema, error) {
+	chain := opts.refChain[:len(opts.refChain):len(opts.refChain)]
+	schemaPath := opts.schemaPath
+	follow := func(ref string) error {
+		key := path + " " + ref
+		for _, k := range chain {
+			if k == key {
+				return fmt.Errorf("circular reference %q in %q", ref, path)
+			}
+		}
+		chain = append(chain, key)
+		return nil
+	}
+
+	for {
+		if keyword, ref, ok := schemaDynamicRef(schema); ok && schema.Ref.String() == "" {
+			if ref != "#" {
+				return nil, fmt.Errorf("unsupported %s %v in %q: only references to the root schema are supported", keyword, ref, path)
+			}
+			if err := follow(keyword + " #"); err != nil {
+				return nil, err
+			}
+			schemaPath = "#"
+			rootSchema, ok := root.(*spec.Schema)
+			if !ok {
+				return nil, fmt.Errorf("cannot resolve %s in %q: root is not a schema", keyword, path)
+			}
+			schema = rootSchema
+			continue
+		}
+
+		ref := schema.Ref.String()
+		if ref == "" {
+			break
+		}
+		if err := follow(ref); err != nil {
+			return nil, err
+		}
+		schemaPath = ref
+
+		if schema.Ref.HasFragmentOnly {
+			rootSchema, ok := root.(*spec.Schema)
+			if !ok {
+				return nil, fmt.Errorf("cannot resolve reference %q in %q: root is not a schema", ref, path)
+			}
+			resolved, err := rootSchema.ResolvePointer(schema.Ref.GetPointer().DecodedTokens())
+			if err != nil {
+				return nil, fmt.Errorf("cannot resolve reference %q in %q: %v", ref, path, err)
+			}
+			schema = resolved
+			continue
+		}
+
+		if opts.RefResolver == nil {
+			return nil, unresolvableSchemaRefMsg(path, ref)
+		}
+		resolved, err := opts.RefResolver(ref)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve reference %q in %q: %v", ref, path, err)
+		}
+		if resolved == nil {
+			return nil, fmt.Errorf("cannot resolve reference %q in %q: schema not found", ref, path)
+		}
+		schema = resolved
+		root = resolved
+	}
+	return &resolvedSchema{schema: schema, root: root, chain: chain, schemaPath: schemaPath}, nil
+}
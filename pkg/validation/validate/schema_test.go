@@ -16,6 +16,7 @@ package validate
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"reflect"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-openapi/swag"
+	"k8s.io/kube-openapi/pkg/validation/errors"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
@@ -106,26 +108,24 @@ func TestSchemaValidator_PatternProperties(t *testing.T) {
 
 }
 
-func TestSchemaValidator_ReferencePanic(t *testing.T) {
-	assert.PanicsWithValue(t, `schema references not supported: http://localhost:1234/integer.json`, schemaRefValidator)
-}
-
-func schemaRefValidator() {
-	var schemaJSON = `
-{
-    "$ref": "http://localhost:1234/integer.json"
-}`
-
+func TestSchemaValidator_ReferenceWithoutResolver(t *testing.T) {
 	schema := new(spec.Schema)
-	_ = json.Unmarshal([]byte(schemaJSON), schema)
-
-	var input map[string]interface{}
-
-	// ok
-	var inputJSON = `{"name": "Ivan","address-1": "sesame street"}`
-	_ = json.Unmarshal([]byte(inputJSON), &input)
-	// panics
-	_ = AgainstSchema(schema, input, strfmt.Default)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"$ref": "http://localhost:1234/integer.json"}
+		}
+	}`), schema))
+
+	// the reference is only reported when validating the values it applies to
+	assert.NoError(t, AgainstSchema(schema, map[string]interface{}{}, strfmt.Default))
+	err := AgainstSchema(schema, map[string]interface{}{"name": "Ivan"}, strfmt.Default)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `schema reference http://localhost:1234/integer.json in "name" cannot be resolved: no reference resolver is set`)
+	}
+
+	ref := spec.RefSchema("http://localhost:1234/integer.json")
+	assert.Error(t, AgainstSchema(ref, 1, strfmt.Default))
 }
 
 // Test edge cases in schemaValidator which are difficult
@@ -183,3 +183,360 @@ func TestSchemaValidator_EdgeCases(t *testing.T) {
 	r = s.Validate(j)
 	assert.False(t, r.IsValid())
 }
+
+func TestSchemaValidator_ErrorAggregation(t *testing.T) {
+	var schemaJSON = `
+{
+    "type": "array",
+    "items": {
+        "type": "object",
+        "properties": {
+            "name": {
+                "type": "string"
+            }
+        },
+        "required": ["name"]
+    }
+}`
+
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	input := []interface{}{}
+	for i := 0; i < 100; i++ {
+		input = append(input, map[string]interface{}{"name": i})
+	}
+	input = append(input, map[string]interface{}{})
+
+	// default: full list of errors
+	err := AgainstSchema(schema, input, strfmt.Default)
+	require.Error(t, err)
+	assert.Len(t, err.(*errors.CompositeError).Errors, 101)
+
+	err = AgainstSchema(schema, input, strfmt.Default, EnableErrorAggregation(true))
+	require.Error(t, err)
+	errs := err.(*errors.CompositeError).Errors
+	require.Len(t, errs, 2)
+
+	typeErr := errs[0].(*errors.Validation)
+	assert.EqualValues(t, errors.InvalidTypeCode, typeErr.Code())
+	assert.Equal(t, ".*.name", typeErr.Name)
+	assert.Equal(t, 100, typeErr.Value)
+	assert.Equal(t, []interface{}{".0.name", ".1.name", ".2.name"}, typeErr.Values)
+	assert.Contains(t, typeErr.Error(), "100 errors at .*.name")
+
+	// a single error is not aggregated
+	requiredErr := errs[1].(*errors.Validation)
+	assert.EqualValues(t, errors.RequiredFailCode, requiredErr.Code())
+	assert.Equal(t, ".100.name", requiredErr.Name)
+}
+
+func TestSchemaValidator_LocalRef(t *testing.T) {
+	var schemaJSON = `
+{
+    "definitions": {
+        "Name": {
+            "type": "string",
+            "minLength": 2
+        },
+        "Person": {
+            "type": "object",
+            "properties": {
+                "name": {"$ref": "#/definitions/Name"},
+                "children": {
+                    "type": "array",
+                    "items": {"$ref": "#/definitions/Person"}
+                }
+            },
+            "required": ["name"]
+        }
+    },
+    "$ref": "#/definitions/Person"
+}`
+
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	var input map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Ivan", "children": [{"name": "Al"}]}`), &input))
+	assert.NoError(t, AgainstSchema(schema, input, strfmt.Default))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Ivan", "children": [{"name": "A"}, {}]}`), &input))
+	err := AgainstSchema(schema, input, strfmt.Default)
+	require.Error(t, err)
+	assert.Len(t, err.(*errors.CompositeError).Errors, 2)
+}
+
+func TestSchemaValidator_ExternalRef(t *testing.T) {
+	var schemaJSON = `
+{
+    "type": "object",
+    "properties": {
+        "count": {"$ref": "http://localhost:1234/integer.json"},
+        "tags": {"$ref": "tags.json#/definitions/Tags"}
+    }
+}`
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	var tagsJSON = `
+{
+    "definitions": {
+        "Tag": {"type": "string"},
+        "Tags": {
+            "type": "array",
+            "items": {"$ref": "#/definitions/Tag"}
+        }
+    }
+}`
+	tags := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(tagsJSON), tags))
+
+	var resolved []string
+	resolver := func(ref string) (*spec.Schema, error) {
+		resolved = append(resolved, ref)
+		switch ref {
+		case "http://localhost:1234/integer.json":
+			return spec.Int64Property(), nil
+		case "tags.json#/definitions/Tags":
+			// the fragment is resolved against the returned document
+			doc := *tags
+			doc.Ref = spec.MustCreateRef("#/definitions/Tags")
+			return &doc, nil
+		}
+		return nil, fmt.Errorf("unknown ref %s", ref)
+	}
+
+	var input map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"count": 1, "tags": ["a", "b"]}`), &input))
+	assert.NoError(t, AgainstSchemaWithRefResolver(schema, input, strfmt.Default, resolver))
+	assert.ElementsMatch(t, []string{"http://localhost:1234/integer.json", "tags.json#/definitions/Tags"}, resolved)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"count": "one", "tags": ["a", 2]}`), &input))
+	err := AgainstSchemaWithRefResolver(schema, input, strfmt.Default, resolver)
+	require.Error(t, err)
+	assert.Len(t, err.(*errors.CompositeError).Errors, 2)
+
+	// resolution errors are reported as validation errors
+	schema.Properties["other"] = *spec.RefSchema("other.json")
+	require.NoError(t, json.Unmarshal([]byte(`{"other": 1}`), &input))
+	err = AgainstSchemaWithRefResolver(schema, input, strfmt.Default, resolver)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown ref other.json")
+}
+
+func TestSchemaValidator_CircularRef(t *testing.T) {
+	var schemaJSON = `
+{
+    "definitions": {
+        "A": {"$ref": "#/definitions/B"},
+        "B": {"allOf": [{"$ref": "#/definitions/A"}]}
+    },
+    "$ref": "#/definitions/A"
+}`
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	err := AgainstSchema(schema, map[string]interface{}{}, strfmt.Default)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular reference")
+}
+
+func TestSchemaValidator_RecursiveRef(t *testing.T) {
+	var schemaJSON = `
+{
+    "type": "object",
+    "properties": {
+        "value": {"type": "integer"},
+        "children": {
+            "type": "array",
+            "items": {"$recursiveRef": "#"}
+        },
+        "parent": {"$dynamicRef": "#"}
+    },
+    "required": ["value"]
+}`
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	var input map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"value": 1, "children": [{"value": 2, "children": [{"value": 3}]}, {"value": 4, "parent": {"value": 1}}]}`), &input))
+	assert.NoError(t, AgainstSchema(schema, input, strfmt.Default))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"value": 1, "children": [{"value": 2, "children": [{"value": "three"}, {}]}]}`), &input))
+	err := AgainstSchema(schema, input, strfmt.Default)
+	require.Error(t, err)
+	assert.Len(t, err.(*errors.CompositeError).Errors, 2)
+
+	// references which do not point at the root are not supported
+	schema.Properties["other"] = spec.Schema{ExtraProps: map[string]interface{}{"$dynamicRef": "#node"}}
+	require.NoError(t, json.Unmarshal([]byte(`{"value": 1, "other": {}}`), &input))
+	err = AgainstSchema(schema, input, strfmt.Default)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported $dynamicRef #node")
+
+	// a root referencing itself is a cycle
+	schema = &spec.Schema{ExtraProps: map[string]interface{}{"$recursiveRef": "#"}}
+	err = AgainstSchema(schema, input, strfmt.Default)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular reference")
+}
+
+func TestSchemaValidator_SchemaPath(t *testing.T) {
+	var schemaJSON = `
+{
+	"type": "object",
+	"properties": {
+		"spec": {
+			"type": "object",
+			"properties": {
+				"containers": {
+					"type": "array",
+					"items": {"$ref": "#/definitions/Container"}
+				},
+				"a/b": {
+					"allOf": [
+						{"type": "string"},
+						{"maxLength": 3}
+					]
+				}
+			}
+		}
+	},
+	"definitions": {
+		"Container": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"port": {"type": "integer"}
+			}
+		}
+	}
+}`
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), schema))
+
+	var input map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"spec": {"containers": [{"name": "c", "port": "http"}, {}], "a/b": "abcd"}}`), &input))
+
+	res := NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(input)
+	schemaPaths := map[string]string{}
+	for _, e := range res.Errors {
+		// the summary of the allOf failure is not a validation error
+		if v, ok := e.(*errors.Validation); ok {
+			schemaPaths[v.Name] = v.SchemaPath
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"spec.containers.0.port": "#/definitions/Container/properties/port",
+		"spec.containers.1.name": "#/definitions/Container",
+		"spec.a/b":               "#/properties/spec/properties/a~1b/allOf/1",
+	}, schemaPaths)
+}
+
+func TestSchemaValidator_MaxErrors(t *testing.T) {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"items": *spec.ArrayProperty(spec.Int64Property()),
+			},
+		},
+	}
+	items := make([]interface{}, 20)
+	for i := range items {
+		items[i] = fmt.Sprintf("item%d", i)
+	}
+	input := map[string]interface{}{"items": items}
+
+	res := NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(input)
+	assert.Len(t, res.Errors, 20)
+	assert.False(t, res.Truncated)
+
+	res = NewSchemaValidator(schema, nil, "", strfmt.Default, WithMaxErrors(5)).Validate(input)
+	assert.Len(t, res.Errors, 5)
+	assert.True(t, res.Truncated)
+
+	res = NewSchemaValidator(schema, nil, "", strfmt.Default, WithMaxErrors(5)).Validate(map[string]interface{}{"items": items[:3]})
+	assert.Len(t, res.Errors, 3)
+	assert.False(t, res.Truncated)
+
+	// exactly max errors: nothing is dropped
+	res = NewSchemaValidator(schema, nil, "", strfmt.Default, WithMaxErrors(5)).Validate(map[string]interface{}{"items": items[:5]})
+	assert.Len(t, res.Errors, 5)
+	assert.False(t, res.Truncated)
+
+	res = NewSchemaValidator(schema, nil, "", strfmt.Default, WithMaxErrors(5)).Validate(map[string]interface{}{"items": items[:6]})
+	assert.Len(t, res.Errors, 5)
+	assert.True(t, res.Truncated)
+}
+
+func TestSchemaValidator_NumericStrings(t *testing.T) {
+	schema := spec.StrFmtProperty("int32").WithMinimum(1, false).WithMaximum(65535, false)
+
+	// disabled by default
+	assert.True(t, NewSchemaValidator(schema, nil, "port", strfmt.Default).Validate("http").IsValid())
+
+	validate := func(value interface{}) *Result {
+		return NewSchemaValidator(schema, nil, "port", strfmt.Default, WithNumericStrings(true)).Validate(value)
+	}
+	assert.True(t, validate("8080").IsValid())
+	assert.False(t, validate("http").IsValid())
+	assert.False(t, validate("1.5").IsValid())
+	assert.False(t, validate("4294967296").IsValid(), "out of the range of int32")
+	assert.False(t, validate("0").IsValid(), "below minimum")
+	assert.False(t, validate("65536").IsValid(), "above maximum")
+	assert.True(t, validate("65535").IsValid())
+
+	schema = spec.StrFmtProperty("double").WithMaximum(1, true)
+	assert.True(t, validate("0.5").IsValid())
+	assert.True(t, validate("-1e3").IsValid())
+	assert.False(t, validate("1").IsValid(), "exclusive maximum")
+	assert.False(t, validate("one").IsValid())
+
+	// strings without numeric format are not numbers
+	schema = spec.StringProperty()
+	assert.True(t, validate("abc").IsValid())
+}
+
+func TestSchemaValidator_CaseInsensitiveEnums(t *testing.T) {
+	schema := spec.StringProperty().WithEnum("Always", "IfNotPresent", "Never")
+
+	res := NewSchemaValidator(schema, nil, "imagePullPolicy", strfmt.Default).Validate("ifnotpresent")
+	assert.False(t, res.IsValid())
+
+	res = NewSchemaValidator(schema, nil, "imagePullPolicy", strfmt.Default, WithCaseInsensitiveEnums(true)).Validate("ifnotpresent")
+	assert.True(t, res.IsValid())
+	if assert.Len(t, res.Warnings, 1) {
+		assert.Contains(t, res.Warnings[0].Error(), `"IfNotPresent"`)
+	}
+
+	res = NewSchemaValidator(schema, nil, "imagePullPolicy", strfmt.Default, WithCaseInsensitiveEnums(true)).Validate("Never")
+	assert.True(t, res.IsValid())
+	assert.Empty(t, res.Warnings)
+
+	res = NewSchemaValidator(schema, nil, "imagePullPolicy", strfmt.Default, WithCaseInsensitiveEnums(true)).Validate("sometimes")
+	assert.False(t, res.IsValid())
+}
+
+func TestSchemaValidator_DecodedByteLength(t *testing.T) {
+	schema := spec.StrFmtProperty("byte").WithMinLength(2).WithMaxLength(4)
+	// "AAECAw==" encodes 4 bytes in 8 characters, "AA==" 1 byte in 4 characters
+	fourBytes, oneByte := "AAECAw==", "AA=="
+
+	// by default, the length of the string is checked
+	assert.False(t, NewSchemaValidator(schema, nil, "data", strfmt.Default).Validate(fourBytes).IsValid())
+	assert.True(t, NewSchemaValidator(schema, nil, "data", strfmt.Default).Validate(oneByte).IsValid())
+
+	validate := func(value string) *Result {
+		return NewSchemaValidator(schema, nil, "data", strfmt.Default, WithDecodedByteLength(true)).Validate(value)
+	}
+	assert.True(t, validate(fourBytes).IsValid())
+	assert.False(t, validate(oneByte).IsValid(), "too short once decoded")
+	assert.False(t, validate("AAECAwQ=").IsValid(), "too long once decoded")
+	assert.False(t, validate("not base64!").IsValid())
+
+	// other formats are not decoded
+	schema = spec.StringProperty().WithMaxLength(4)
+	assert.False(t, validate(fourBytes).IsValid())
+}
@@ -17,6 +17,7 @@ package validate
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"k8s.io/kube-openapi/pkg/validation/spec"
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
@@ -54,11 +55,14 @@ func (s *schemaSliceValidator) Validate(data interface{}) *Result {
 	size := val.Len()
 
 	if s.Items != nil && s.Items.Schema != nil {
-		validator := NewSchemaValidator(s.Items.Schema, s.Root, s.Path, s.KnownFormats, s.Options.Options()...)
+		validator := NewSchemaValidator(s.Items.Schema, s.Root, s.Path, s.KnownFormats, s.Options.optionsAt("items")...)
 		for i := 0; i < size; i++ {
 			validator.SetPath(fmt.Sprintf("%s.%d", s.Path, i))
 			value := val.Index(i)
 			result.Merge(validator.Validate(value.Interface()))
+			if s.Options.errorLimitReached(result) {
+				return result
+			}
 		}
 	}
 
@@ -66,11 +70,14 @@ func (s *schemaSliceValidator) Validate(data interface{}) *Result {
 	if s.Items != nil && len(s.Items.Schemas) > 0 {
 		itemsSize = len(s.Items.Schemas)
 		for i := 0; i < itemsSize; i++ {
-			validator := NewSchemaValidator(&s.Items.Schemas[i], s.Root, fmt.Sprintf("%s.%d", s.Path, i), s.KnownFormats, s.Options.Options()...)
+			validator := NewSchemaValidator(&s.Items.Schemas[i], s.Root, fmt.Sprintf("%s.%d", s.Path, i), s.KnownFormats, s.Options.optionsAt("items", strconv.Itoa(i))...)
 			if val.Len() <= i {
 				break
 			}
 			result.Merge(validator.Validate(val.Index(i).Interface()))
+			if s.Options.errorLimitReached(result) {
+				return result
+			}
 		}
 	}
 	if s.AdditionalItems != nil && itemsSize < size {
@@ -78,9 +85,13 @@ func (s *schemaSliceValidator) Validate(data interface{}) *Result {
 			result.AddErrors(arrayDoesNotAllowAdditionalItemsMsg())
 		}
 		if s.AdditionalItems.Schema != nil {
-			for i := itemsSize; i < size-itemsSize+1; i++ {
-				validator := NewSchemaValidator(s.AdditionalItems.Schema, s.Root, fmt.Sprintf("%s.%d", s.Path, i), s.KnownFormats, s.Options.Options()...)
+			validator := NewSchemaValidator(s.AdditionalItems.Schema, s.Root, s.Path, s.KnownFormats, s.Options.optionsAt("additionalItems")...)
+			for i := itemsSize; i < size; i++ {
+				validator.SetPath(fmt.Sprintf("%s.%d", s.Path, i))
 				result.Merge(validator.Validate(val.Index(i).Interface()))
+				if s.Options.errorLimitReached(result) {
+					return result
+				}
 			}
 		}
 	}
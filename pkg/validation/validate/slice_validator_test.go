@@ -15,9 +15,14 @@
 package validate
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // Test edge cases in slice_validator which are difficult
@@ -32,3 +37,70 @@ func TestSliceValidator_EdgeCases(t *testing.T) {
 	assert.NotNil(t, r)
 	assert.True(t, r.IsValid())
 }
+
+func TestSliceValidator_AdditionalItems(t *testing.T) {
+	tuple := func(additionalItems string) *spec.Schema {
+		schema := new(spec.Schema)
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"type": "array",
+			"items": [{"type": "string"}, {"type": "integer"}],
+			"additionalItems": `+additionalItems+`
+		}`), schema))
+		return schema
+	}
+
+	// additionalItems: false rejects the items beyond the tuple
+	noAdditional := tuple("false")
+	assert.NoError(t, AgainstSchema(noAdditional, []interface{}{"a", 1}, strfmt.Default))
+	assert.NoError(t, AgainstSchema(noAdditional, []interface{}{"a"}, strfmt.Default))
+	err := AgainstSchema(noAdditional, []interface{}{"a", 1, "extra"}, strfmt.Default)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), ArrayDoesNotAllowAdditionalItemsError)
+	}
+
+	// a schema validates every item beyond the tuple
+	additionalBooleans := tuple(`{"type": "boolean"}`)
+	assert.NoError(t, AgainstSchema(additionalBooleans, []interface{}{"a", 1, true, false, true}, strfmt.Default))
+	res := NewSchemaValidator(additionalBooleans, nil, "", strfmt.Default).Validate([]interface{}{"a", 1, true, "b", "c"})
+	assert.Len(t, res.Errors, 2)
+	err = AgainstSchema(additionalBooleans, []interface{}{"a", 1, true, false, "last"}, strfmt.Default)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), ".4 in body must be of type boolean")
+	}
+
+	// the schema is kept when marshaling
+	b, err := json.Marshal(additionalBooleans)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"additionalItems":{"type":"boolean"}`)
+}
+
+func TestSliceValidator_ItemPaths(t *testing.T) {
+	schema := new(spec.Schema)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {"name": {"type": "string"}},
+			"anyOf": [{"required": ["name"]}, {"required": ["id"]}],
+			"not": {"required": ["forbidden"]}
+		}
+	}`), schema))
+
+	// a single validator validates all the items, with the path of each item
+	res := NewSchemaValidator(schema, nil, "list", strfmt.Default).Validate([]interface{}{
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"name": 1},
+		map[string]interface{}{},
+		map[string]interface{}{"name": "d", "forbidden": true},
+	})
+	var messages []string
+	for _, e := range res.Errors {
+		messages = append(messages, e.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		"list.1.name in body must be of type string: \"integer\"",
+		"\"list.2\" must validate at least one schema (anyOf)",
+		"list.2.name in body is required",
+		"\"list.3\" must not validate the schema (not)",
+	}, messages)
+}
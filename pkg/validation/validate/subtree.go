@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+)
+
+// pointerTokenUnescaper decodes a JSON pointer token, see RFC 6901.
+var pointerTokenUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ValidateSubtree validates value, the part of an instance of root found at instancePointer (a JSON pointer,
+// e.g. "/spec/containers/0"), against the schema which applies to that part of the instance. This allows
+// re-validating only what changed after a patch.
+//
+// Local $refs are resolved against the definitions of rootSwagger if it is set, against root otherwise.
+// An error is returned if no schema applies at instancePointer, e.g. if it designates a property which
+// the schema does not allow.
+func ValidateSubtree(root *spec.Schema, rootSwagger *spec.Swagger, instancePointer string, value interface{}) (*Result, error) {
+	if !strfmt.IsJSONPointer(instancePointer) {
+		return nil, fmt.Errorf("invalid instance pointer %q", instancePointer)
+	}
+	var refRoot interface{} = root
+	if rootSwagger != nil {
+		refRoot = &spec.Schema{SchemaProps: spec.SchemaProps{Definitions: rootSwagger.Definitions}}
+	}
+	opts := &SchemaValidatorOptions{}
+
+	schema := root
+	var path []string
+	for _, token := range strings.Split(instancePointer, "/")[1:] {
+		token = pointerTokenUnescaper.Replace(token)
+		if hasSchemaRef(schema) {
+			resolved, err := resolveSchemaRef(schema, refRoot, strings.Join(path, "."), opts)
+			if err != nil {
+				return nil, err
+			}
+			schema, refRoot = resolved.schema, resolved.root
+		}
+		next, err := instanceChildSchema(schema, token)
+		if err != nil {
+			return nil, fmt.Errorf("no schema for %q in %q: %v", token, instancePointer, err)
+		}
+		schema = next
+		path = append(path, token)
+	}
+
+	return NewSchemaValidator(schema, refRoot, strings.Join(path, "."), strfmt.Default).Validate(value), nil
+}
+
+// instanceChildSchema returns the schema of the property or of the array item named token of an
+// instance of schema.
+func instanceChildSchema(schema *spec.Schema, token string) (*spec.Schema, error) {
+	if index, err := strconv.Atoi(token); err == nil && index >= 0 && schema.Items != nil {
+		if schema.Items.Schema != nil {
+			return schema.Items.Schema, nil
+		}
+		if index < len(schema.Items.Schemas) {
+			return &schema.Items.Schemas[index], nil
+		}
+		if schema.AdditionalItems != nil && schema.AdditionalItems.Schema != nil {
+			return schema.AdditionalItems.Schema, nil
+		}
+		return nil, fmt.Errorf("no schema for item %d", index)
+	}
+
+	if property, ok := schema.Properties[token]; ok {
+		return &property, nil
+	}
+	for pattern, property := range schema.PatternProperties {
+		if matches, _ := regexp.MatchString(pattern, token); matches {
+			return &property, nil
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		if schema.AdditionalProperties.Schema != nil {
+			return schema.AdditionalProperties.Schema, nil
+		}
+		if schema.AdditionalProperties.Allows {
+			return &spec.Schema{}, nil
+		}
+		return nil, fmt.Errorf("additional properties are not allowed")
+	}
+	if len(schema.Properties) > 0 || len(schema.PatternProperties) > 0 {
+		// properties which are not described are allowed by default
+		return &spec.Schema{}, nil
+	}
+	return nil, fmt.Errorf("schema has neither properties nor items")
+}
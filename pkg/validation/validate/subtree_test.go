@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestValidateSubtree(t *testing.T) {
+	container := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:     []string{"object"},
+			Required: []string{"name"},
+			Properties: map[string]spec.Schema{
+				"name":  *spec.StringProperty(),
+				"ports": *spec.ArrayProperty(spec.Int64Property()),
+			},
+		},
+	}
+	root := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type: []string{"object"},
+			Properties: map[string]spec.Schema{
+				"spec": {
+					SchemaProps: spec.SchemaProps{
+						Type: []string{"object"},
+						Properties: map[string]spec.Schema{
+							"containers": *spec.ArrayProperty(spec.RefSchema("#/definitions/Container")),
+							"labels":     *spec.MapProperty(spec.StringProperty()),
+						},
+					},
+				},
+			},
+		},
+	}
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Definitions: spec.Definitions{"Container": container},
+	}}
+
+	// nested property subtree
+	res, err := ValidateSubtree(root, swagger, "/spec/labels", map[string]interface{}{"app": "web"})
+	require.NoError(t, err)
+	assert.True(t, res.IsValid())
+	res, err = ValidateSubtree(root, swagger, "/spec/labels/app", int64(1))
+	require.NoError(t, err)
+	if assert.Len(t, res.Errors, 1) {
+		assert.Contains(t, res.Errors[0].Error(), "spec.labels.app")
+	}
+
+	// array element subtree, through a reference
+	res, err = ValidateSubtree(root, swagger, "/spec/containers/0", map[string]interface{}{"name": "web"})
+	require.NoError(t, err)
+	assert.True(t, res.IsValid())
+	res, err = ValidateSubtree(root, swagger, "/spec/containers/1", map[string]interface{}{"ports": []interface{}{int64(80)}})
+	require.NoError(t, err)
+	if assert.Len(t, res.Errors, 1) {
+		assert.Contains(t, res.Errors[0].Error(), "spec.containers.1.name")
+	}
+	res, err = ValidateSubtree(root, swagger, "/spec/containers/0/ports/0", "http")
+	require.NoError(t, err)
+	assert.False(t, res.IsValid())
+
+	// the whole document
+	res, err = ValidateSubtree(root, swagger, "", map[string]interface{}{"spec": map[string]interface{}{}})
+	require.NoError(t, err)
+	assert.True(t, res.IsValid())
+
+	_, err = ValidateSubtree(root, swagger, "spec", nil)
+	assert.Error(t, err)
+	_, err = ValidateSubtree(root, swagger, "/spec/containers/0/ports/0/unknown", nil)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package validate
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 
@@ -24,6 +25,34 @@ import (
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
+// TypeOf returns the OpenAPI type of value ("string", "integer", "number", "boolean", "array", "object"
+// or "null") as classified by the validation: strfmt types are strings, integer kinds are integers,
+// float kinds are numbers, and a json.Number is an integer if it parses as one, a number otherwise.
+// It returns an empty string for values which have no OpenAPI type, like channels or functions.
+func TypeOf(value interface{}) string {
+	if value == nil {
+		return nullType
+	}
+	if n, ok := value.(json.Number); ok {
+		if _, err := n.Int64(); err == nil {
+			return integerType
+		}
+		return numberType
+	}
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return nullType
+		}
+		return TypeOf(val.Elem().Interface())
+	case reflect.Interface, reflect.Array, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128, reflect.Uintptr:
+		return ""
+	}
+	schType, _ := (&typeValidator{}).schemaInfoForType(value)
+	return schType
+}
+
 type typeValidator struct {
 	Type     spec.StringOrArray
 	Nullable bool
@@ -81,6 +110,18 @@ func (t *typeValidator) schemaInfoForType(data interface{}) (string, string) {
 		return stringType, stringFormatUUID4
 	case strfmt.UUID5, *strfmt.UUID5:
 		return stringType, stringFormatUUID5
+	case strfmt.JSONPointer, *strfmt.JSONPointer:
+		return stringType, stringFormatJSONPointer
+	case strfmt.RelativeJSONPointer, *strfmt.RelativeJSONPointer:
+		return stringType, stringFormatRelativeJSONPointer
+	case strfmt.Regex, *strfmt.Regex:
+		return stringType, stringFormatRegex
+	case strfmt.IRI, *strfmt.IRI:
+		return stringType, stringFormatIRI
+	case strfmt.IRIReference, *strfmt.IRIReference:
+		return stringType, stringFormatIRIReference
+	case strfmt.URITemplate, *strfmt.URITemplate:
+		return stringType, stringFormatURITemplate
 	// TODO: missing binary (io.ReadCloser)
 	// TODO: missing json.Number
 	default:
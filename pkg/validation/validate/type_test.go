@@ -15,6 +15,7 @@
 package validate
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -261,3 +262,35 @@ func TestType_schemaInfoForType(t *testing.T) {
 		assert.Equal(t, x.expectedSwaggerFormat, swaggerFormat)
 	}
 }
+
+func TestTypeOf(t *testing.T) {
+	var nilPointer *string
+	str := "abc"
+	for _, test := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: "abc", expected: stringType},
+		{value: &str, expected: stringType},
+		{value: strfmt.DateTime(time.Date(2014, 10, 10, 0, 0, 0, 0, time.UTC)), expected: stringType},
+		{value: []byte("abc"), expected: stringType},
+		{value: 1, expected: integerType},
+		{value: int8(1), expected: integerType},
+		{value: uint64(1), expected: integerType},
+		{value: 1.5, expected: numberType},
+		{value: float32(1), expected: numberType},
+		{value: json.Number("12"), expected: integerType},
+		{value: json.Number("-12"), expected: integerType},
+		{value: json.Number("12.5"), expected: numberType},
+		{value: json.Number("1e3"), expected: numberType},
+		{value: true, expected: booleanType},
+		{value: []interface{}{1, "a"}, expected: arrayType},
+		{value: map[string]interface{}{"a": 1}, expected: objectType},
+		{value: struct{ A int }{A: 1}, expected: objectType},
+		{value: nil, expected: nullType},
+		{value: nilPointer, expected: nullType},
+		{value: make(chan int), expected: ""},
+	} {
+		assert.Equal(t, test.expected, TypeOf(test.value), "TypeOf(%#v)", test.value)
+	}
+}
@@ -15,7 +15,11 @@
 package validate
 
 import (
+	"encoding/base64"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"k8s.io/kube-openapi/pkg/validation/errors"
 	"k8s.io/kube-openapi/pkg/validation/spec"
@@ -37,6 +41,8 @@ type basicCommonValidator struct {
 	In      string
 	Default interface{}
 	Enum    []interface{}
+	// CaseInsensitive matches strings against string enum values ignoring case
+	CaseInsensitive bool
 }
 
 func (b *basicCommonValidator) SetPath(path string) {
@@ -64,11 +70,29 @@ func (b *basicCommonValidator) Validate(data interface{}) (res *Result) {
 				}
 			}
 		}
+		if res := b.validateCaseInsensitive(data); res != nil {
+			return res
+		}
 		return errorHelp.sErr(errors.EnumFail(b.Path, b.In, data, b.Enum))
 	}
 	return nil
 }
 
+// validateCaseInsensitive returns a result warning about the canonical spelling if data is a string
+// matching a string enum value when ignoring case, nil otherwise.
+func (b *basicCommonValidator) validateCaseInsensitive(data interface{}) *Result {
+	str, ok := data.(string)
+	if !b.CaseInsensitive || !ok {
+		return nil
+	}
+	for _, enumValue := range b.Enum {
+		if canonical, ok := enumValue.(string); ok && strings.EqualFold(str, canonical) {
+			return &Result{Warnings: []error{fmt.Errorf("%s in %s should be %q instead of %q", b.Path, b.In, canonical, str)}}
+		}
+	}
+	return nil
+}
+
 type numberValidator struct {
 	Path             string
 	In               string
@@ -196,6 +220,9 @@ type stringValidator struct {
 	Pattern   string
 	Path      string
 	In        string
+	// DecodedLength applies MaxLength and MinLength to the number of bytes encoded
+	// in base64 by the string, see SchemaValidatorOptions.DecodedByteLength
+	DecodedLength bool
 }
 
 func (s *stringValidator) SetPath(path string) {
@@ -219,15 +246,21 @@ func (s *stringValidator) Validate(val interface{}) *Result {
 		return errorHelp.sErr(errors.InvalidType(s.Path, s.In, stringType, val))
 	}
 
-	if s.MaxLength != nil {
-		if err := MaxLength(s.Path, s.In, data, *s.MaxLength); err != nil {
-			return errorHelp.sErr(err)
+	if s.DecodedLength {
+		if res := s.validateDecodedLength(data); res != nil {
+			return res
+		}
+	} else {
+		if s.MaxLength != nil {
+			if err := MaxLength(s.Path, s.In, data, *s.MaxLength); err != nil {
+				return errorHelp.sErr(err)
+			}
 		}
-	}
 
-	if s.MinLength != nil {
-		if err := MinLength(s.Path, s.In, data, *s.MinLength); err != nil {
-			return errorHelp.sErr(err)
+		if s.MinLength != nil {
+			if err := MinLength(s.Path, s.In, data, *s.MinLength); err != nil {
+				return errorHelp.sErr(err)
+			}
 		}
 	}
 
@@ -238,3 +271,94 @@ func (s *stringValidator) Validate(val interface{}) *Result {
 	}
 	return nil
 }
+
+// validateDecodedLength checks the length of the bytes encoded in base64 by data. Data which is not
+// valid base64 is left to the format validator.
+func (s *stringValidator) validateDecodedLength(data string) *Result {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil
+	}
+	length := int64(len(decoded))
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return errorHelp.sErr(errors.TooLong(s.Path, s.In, *s.MaxLength, data))
+	}
+	if s.MinLength != nil && length < *s.MinLength {
+		return errorHelp.sErr(errors.TooShort(s.Path, s.In, *s.MinLength, data))
+	}
+	return nil
+}
+
+// numericStringValidator validates strings encoding numbers, i.e. the string values of a schema
+// of type string with a numeric format, see SchemaValidatorOptions.NumericStrings.
+type numericStringValidator struct {
+	Path             string
+	In               string
+	Enabled          bool
+	Format           string
+	Maximum          *float64
+	ExclusiveMaximum bool
+	Minimum          *float64
+	ExclusiveMinimum bool
+}
+
+func (n *numericStringValidator) SetPath(path string) {
+	n.Path = path
+}
+
+func (n *numericStringValidator) Applies(source interface{}, kind reflect.Kind) bool {
+	switch source.(type) {
+	case *spec.Schema:
+		_, _, numeric := numericStringFormat(n.Format)
+		return n.Enabled && numeric && kind == reflect.String
+	}
+	return false
+}
+
+func (n *numericStringValidator) Validate(val interface{}) *Result {
+	str, ok := val.(string)
+	if !ok {
+		return nil
+	}
+	isInt, bitSize, _ := numericStringFormat(n.Format)
+
+	var number interface{}
+	var err error
+	switch {
+	case n.Format == integerFormatUInt32 || n.Format == integerFormatUInt64:
+		number, err = strconv.ParseUint(str, 10, bitSize)
+	case isInt:
+		number, err = strconv.ParseInt(str, 10, bitSize)
+	default:
+		number, err = strconv.ParseFloat(str, bitSize)
+	}
+	if err != nil {
+		return errorHelp.sErr(errors.InvalidType(n.Path, n.In, n.Format, str))
+	}
+
+	numbers := &numberValidator{
+		Path:             n.Path,
+		In:               n.In,
+		Maximum:          n.Maximum,
+		ExclusiveMaximum: n.ExclusiveMaximum,
+		Minimum:          n.Minimum,
+		ExclusiveMinimum: n.ExclusiveMinimum,
+	}
+	return numbers.Validate(number)
+}
+
+// numericStringFormat returns whether format is an integer or a floating point number format, and
+// the bit size of its numbers. numeric is false for the other formats.
+func numericStringFormat(format string) (isInt bool, bitSize int, numeric bool) {
+	switch format {
+	case integerFormatInt32, integerFormatUInt32:
+		return true, 32, true
+	case integerFormatInt64, integerFormatUInt64, integerType:
+		return true, 64, true
+	case numberFormatFloat, numberFormatFloat32:
+		return false, 32, true
+	case numberFormatDouble, numberFormatFloat64, numberType:
+		return false, 64, true
+	}
+	return false, 0, false
+}
@@ -15,8 +15,11 @@
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"unicode/utf8"
 
 	"github.com/go-openapi/swag"
@@ -72,7 +75,10 @@ func MaxItems(path, in string, size, max int64) *errors.Validation {
 	return nil
 }
 
-// UniqueItems validates that the provided slice has unique elements
+// UniqueItems validates that the provided slice has unique elements.
+//
+// Elements are compared structurally: objects are equal when they have the same keys with equal
+// values, whatever their order, and numbers are compared by value whatever their Go type.
 func UniqueItems(path, in string, data interface{}) *errors.Validation {
 	val := reflect.ValueOf(data)
 	if val.Kind() != reflect.Slice {
@@ -80,7 +86,7 @@ func UniqueItems(path, in string, data interface{}) *errors.Validation {
 	}
 	var unique []interface{}
 	for i := 0; i < val.Len(); i++ {
-		v := val.Index(i).Interface()
+		v := normalizeItem(val.Index(i).Interface())
 		for _, u := range unique {
 			if reflect.DeepEqual(v, u) {
 				return errors.DuplicateItems(path, in)
@@ -91,6 +97,85 @@ func UniqueItems(path, in string, data interface{}) *errors.Validation {
 	return nil
 }
 
+// normalizeFloat converts the integral values of f to int64, or to uint64 beyond the int64 range, so
+// that they compare equal to the same integers, which are normalized to int64 to stay exact.
+func normalizeFloat(f float64) interface{} {
+	if f != math.Trunc(f) || math.IsInf(f, 0) {
+		return f
+	}
+	if f >= -(1<<63) && f < 1<<63 {
+		return int64(f)
+	}
+	if f >= 0 && f < 1<<64 {
+		return uint64(f)
+	}
+	return f
+}
+
+// normalizeItem converts v to its JSON representation made of map[string]interface{},
+// []interface{}, int64, uint64, float64, string, bool and nil values, so that equal items are
+// DeepEqual. Numbers are int64, or uint64 beyond the int64 range, if integral, float64 otherwise.
+func normalizeItem(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+			return u
+		}
+		if f, err := n.Float64(); err == nil {
+			return normalizeFloat(f)
+		}
+		return n.String()
+	}
+
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return normalizeItem(val.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := val.Uint(); u > math.MaxInt64 {
+			return u
+		}
+		return int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return normalizeFloat(val.Float())
+	case reflect.String:
+		return val.String()
+	case reflect.Bool:
+		return val.Bool()
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return nil
+		}
+		ret := make([]interface{}, val.Len())
+		for i := range ret {
+			ret[i] = normalizeItem(val.Index(i).Interface())
+		}
+		return ret
+	case reflect.Map:
+		if val.IsNil() {
+			return nil
+		}
+		ret := make(map[string]interface{}, val.Len())
+		for _, k := range val.MapKeys() {
+			ret[fmt.Sprintf("%v", k.Interface())] = normalizeItem(val.MapIndex(k).Interface())
+		}
+		return ret
+	case reflect.Struct:
+		return normalizeItem(swag.ToDynamicJSON(v))
+	}
+	return v
+}
+
 // MinLength validates a string for minimum length
 func MinLength(path, in, data string, minLength int64) *errors.Validation {
 	strLen := int64(utf8.RuneCount([]byte(data)))
@@ -125,7 +210,7 @@ func Required(path, in string, data interface{}) *errors.Validation {
 func Pattern(path, in, data, pattern string) *errors.Validation {
 	re, err := compileRegexp(pattern)
 	if err != nil {
-		return errors.FailedPattern(path, in, fmt.Sprintf("%s, but pattern is invalid: %s", pattern, err.Error()), data)
+		return errors.InvalidPattern(path, in, pattern, err, data)
 	}
 	if !re.MatchString(data) {
 		return errors.FailedPattern(path, in, pattern, data)
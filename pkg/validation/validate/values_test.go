@@ -15,10 +15,12 @@
 package validate
 
 import (
+	"encoding/json"
 	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/kube-openapi/pkg/validation/errors"
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
@@ -83,6 +85,40 @@ func TestValues_ValidateUniqueItems(t *testing.T) {
 	}
 }
 
+func TestValues_ValidateUniqueItemsOfObjects(t *testing.T) {
+	decode := func(s string) interface{} {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+
+	// unique objects and arrays
+	assert.Nil(t, UniqueItems("test", "body", decode(`[{"a": 1, "b": [1, 2]}, {"a": 1, "b": [2, 1]}, {"a": 2}, [1, 2], [2, 1]]`)))
+
+	// duplicate objects
+	assert.Error(t, UniqueItems("test", "body", decode(`[{"a": 1, "b": {"c": "d"}}, {"a": 2}, {"a": 1, "b": {"c": "d"}}]`)))
+
+	// equal objects with differently ordered keys
+	assert.Error(t, UniqueItems("test", "body", decode(`[{"a": 1, "b": {"c": "d", "e": "f"}}, {"b": {"e": "f", "c": "d"}, "a": 1}]`)))
+
+	// duplicate arrays
+	assert.Error(t, UniqueItems("test", "body", decode(`[[1, {"a": 1}], [1, {"a": 1}]]`)))
+
+	// objects which are equal once numbers are compared by value
+	assert.Error(t, UniqueItems("test", "body", []interface{}{
+		map[string]interface{}{"a": int64(1), "b": []interface{}{int32(2)}},
+		map[string]interface{}{"b": []interface{}{float64(2)}, "a": json.Number("1")},
+	}))
+
+	// integers beyond the precision of float64 are compared exactly
+	assert.Nil(t, UniqueItems("test", "body", []interface{}{int64(1<<53 + 1), int64(1 << 53), json.Number("9007199254740995"), uint64(1<<63 + 1)}))
+	assert.Nil(t, UniqueItems("test", "body", []interface{}{json.Number("18446744073709551615"), uint64(1<<64 - 2)}))
+	assert.Error(t, UniqueItems("test", "body", []interface{}{json.Number("18446744073709551615"), uint64(1<<64 - 1)}))
+	assert.Error(t, UniqueItems("test", "body", []interface{}{int64(1<<53 + 1), json.Number("9007199254740993")}))
+	assert.Error(t, UniqueItems("test", "body", []interface{}{float64(3), json.Number("3.0"), int8(3)}))
+	assert.Nil(t, UniqueItems("test", "body", []interface{}{1.5, int64(1), json.Number("2.5")}))
+}
+
 func TestValues_ValidateMinLength(t *testing.T) {
 	var minLength int64 = 5
 	err := MinLength("test", "body", "aa", minLength)
@@ -170,6 +206,20 @@ func TestValuMultipleOf(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestValues_Pattern_InvalidSchema(t *testing.T) {
+	// an invalid regexp keeps the PatternFailCode, it is classified as a schema error
+	err := Pattern("path", "in", "pick-a-boo", `.*-[a(-z]-^).*`)
+	if assert.NotNil(t, err) {
+		assert.True(t, err.InvalidSchema)
+		assert.Equal(t, SchemaError, ClassifyError(err))
+	}
+	err = Pattern("path", "in", "pick-a-boo", `^[0-9]+$`)
+	if assert.NotNil(t, err) {
+		assert.False(t, err.InvalidSchema)
+		assert.Equal(t, DataError, ClassifyError(err))
+	}
+}
+
 // Test edge case for Pattern (in regular spec, no invalid regexp should reach there)
 func TestValues_Pattern_Edgecases(t *testing.T) {
 	var err *errors.Validation